@@ -0,0 +1,162 @@
+package twitch_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/joeyak/go-twitch-eventsub/v3/eventsubtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var testClockStart = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func waitForTimers(t *testing.T, clock *eventsubtest.Clock, n int) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, clock.WaitForTimers(ctx, n))
+}
+
+func TestSubscribeBackoffUsesClock(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	clock := eventsubtest.NewClock(testClockStart)
+	client := twitch.NewSubscriptionClientWithUrl(server.URL)
+	client.MinBackoff = time.Hour
+	client.Clock = clock
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Subscribe(context.Background(), validSubscribeRequest())
+		done <- err
+	}()
+
+	waitForTimers(t, clock, 1)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "the retry should wait for the clock")
+
+	clock.Advance(time.Hour)
+	assert.NoError(t, <-done)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestSubscribeRateLimitResetUsesClock(t *testing.T) {
+	t.Parallel()
+
+	reset := testClockStart.Add(30 * time.Second)
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Ratelimit-Limit", "800")
+		w.Header().Set("Ratelimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Ratelimit-Remaining", "0")
+		} else {
+			w.Header().Set("Ratelimit-Remaining", "799")
+		}
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	clock := eventsubtest.NewClock(testClockStart)
+	client := twitch.NewSubscriptionClientWithUrl(server.URL)
+	client.Clock = clock
+
+	_, err := client.Subscribe(context.Background(), validSubscribeRequest())
+	assert.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Subscribe(context.Background(), validSubscribeRequest())
+		done <- err
+	}()
+
+	waitForTimers(t, clock, 1)
+	clock.Advance(29 * time.Second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "request should wait for the reset")
+
+	clock.Advance(time.Second)
+	assert.NoError(t, <-done)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestWebhookMessageAgeUsesClock(t *testing.T) {
+	t.Parallel()
+
+	clock := eventsubtest.NewClock(testClockStart)
+	handler := twitch.NewWebhookHandler(testWebhookSecret)
+	handler.Clock = clock
+	handler.OnError(func(err error) {})
+
+	body := `{"challenge": "challenge", "subscription": {"id": "sub", "type": "stream.online", "version": "1"}}`
+	code, _ := serveWebhook(handler, newWebhookRequest(t, testWebhookSecret, "webhook_callback_verification", testClockStart, body))
+	assert.Equal(t, http.StatusOK, code)
+
+	clock.Advance(11 * time.Minute)
+	code, _ = serveWebhook(handler, newWebhookRequest(t, testWebhookSecret, "webhook_callback_verification", testClockStart, body))
+	assert.Equal(t, http.StatusForbidden, code, "messages older than 10 minutes on the clock should be refused")
+}
+
+func TestReplayUsesClock(t *testing.T) {
+	t.Parallel()
+
+	var recording bytes.Buffer
+	recorder := twitch.NewRecorder(&recording)
+	assert.NoError(t, recorder.Record("", newWelcomeFrame(t, "session"), testClockStart))
+	keepalive, err := eventsubtest.KeepAliveMessage()
+	assert.NoError(t, err)
+	assert.NoError(t, recorder.Record("session", keepalive, testClockStart.Add(time.Minute)))
+
+	clock := eventsubtest.NewClock(testClockStart)
+	source := twitch.NewReplaySource(&recording)
+	source.Speed = 1
+	source.Clock = clock
+
+	var output bytes.Buffer
+	client := twitch.NewClient()
+	client.SetClock(clock)
+	client.SetFrameSource(source)
+	client.SetRecorder(twitch.NewRecorder(&output))
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- client.Connect()
+	}()
+
+	waitForTimers(t, clock, 1)
+	clock.Advance(time.Minute)
+	assert.NoError(t, <-done)
+
+	var times []time.Time
+	decoder := json.NewDecoder(&output)
+	for decoder.More() {
+		var frame twitch.RecordedFrame
+		assert.NoError(t, decoder.Decode(&frame))
+		times = append(times, frame.ReceivedAt)
+	}
+	if assert.Len(t, times, 2) {
+		assert.True(t, testClockStart.Equal(times[0]), "frames are timestamped with the clock")
+		assert.True(t, testClockStart.Add(time.Minute).Equal(times[1]))
+	}
+}
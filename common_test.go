@@ -8,33 +8,25 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
-	_ "embed"
-
 	"github.com/coder/websocket"
 	"github.com/google/uuid"
 	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/joeyak/go-twitch-eventsub/v3/eventsubtest"
 )
 
-//go:embed testEvents.json
-var testEvents []byte
-
 type messageDataGenerator func() ([][]byte, bool, error)
 
 func getTestEventData(eventType twitch.EventSubscription, suffixes ...string) messageDataGenerator {
 	return func() ([][]byte, bool, error) {
-		var events map[string]json.RawMessage
-		if err := json.Unmarshal(testEvents, &events); err != nil {
-			return nil, false, fmt.Errorf("could not parse event json file: %w", err)
-		}
-
-		key := strings.Join(append([]string{string(eventType)}, suffixes...), "-")
-		eventData, ok := events[key]
-		if !ok {
-			return nil, false, fmt.Errorf("could not find %s in testEvents", key)
+		eventData, err := eventsubtest.SampleEvent(eventType, suffixes...)
+		if err != nil {
+			return nil, false, err
 		}
 
 		data, err := json.Marshal(twitch.NotificationMessage{
@@ -175,6 +167,168 @@ func (s *TestServer) sendWelcome(ctx context.Context) error {
 	return s.conn.Write(ctx, websocket.MessageText, data)
 }
 
+type fakeHelixSubscriptions struct {
+	mu            sync.Mutex
+	maxTotalCost  int
+	subscriptions []twitch.PayloadSubscription
+	posts         int
+}
+
+func (h *fakeHelixSubscriptions) postCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.posts
+}
+
+func (h *fakeHelixSubscriptions) revoke(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, subscription := range h.subscriptions {
+		if subscription.ID == id {
+			h.subscriptions = append(h.subscriptions[:i], h.subscriptions[i+1:]...)
+			return
+		}
+	}
+}
+
+func (h *fakeHelixSubscriptions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if r.Header.Get("Authorization") == "Bearer invalid" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		var data []twitch.PayloadSubscription
+		for _, subscription := range h.subscriptions {
+			if subscription.Type == twitch.EventSubscription(r.URL.Query().Get("type")) {
+				data = append(data, subscription)
+			}
+		}
+		json.NewEncoder(w).Encode(twitch.GetSubscriptionsResponse{
+			SubscribeResponse: twitch.SubscribeResponse{Data: data, Total: len(data)},
+		})
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		for i, subscription := range h.subscriptions {
+			if subscription.ID == r.URL.Query().Get("id") {
+				h.subscriptions = append(h.subscriptions[:i], h.subscriptions[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	h.posts++
+
+	var request twitch.SubscriptionRequest
+	json.NewDecoder(r.Body).Decode(&request)
+
+	totalCost := 0
+	for _, subscription := range h.subscriptions {
+		if subscription.Transport.SessionID != request.Transport.SessionID {
+			continue
+		}
+		if subscription.Type == request.Type && fmt.Sprint(subscription.Condition) == fmt.Sprint(request.Condition) {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		totalCost += subscription.Cost
+	}
+
+	subscription := twitch.PayloadSubscription{
+		SubscriptionRequest: request,
+		ID:                  fmt.Sprintf("sub-%d", h.posts),
+		Status:              "enabled",
+		Cost:                1,
+	}
+	h.subscriptions = append(h.subscriptions, subscription)
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(twitch.SubscribeResponse{
+		Data:         []twitch.PayloadSubscription{subscription},
+		Total:        len(h.subscriptions),
+		TotalCost:    totalCost + subscription.Cost,
+		MaxTotalCost: h.maxTotalCost,
+	})
+}
+
+// newScriptedServer starts a websocket server that sends a welcome and then every frame sent on the channel.
+func newScriptedServer(t *testing.T) (string, chan<- []byte) {
+	frames := make(chan []byte, 10)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			panic(err)
+		}
+
+		server := TestServer{conn: conn}
+		err = server.sendWelcome(r.Context())
+		if err != nil {
+			panic(err)
+		}
+
+		go func() {
+			for data := range frames {
+				conn.Write(r.Context(), websocket.MessageText, data)
+			}
+		}()
+
+		// Read so it can close
+		conn.Read(r.Context())
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return fmt.Sprintf("%s/ws", server.URL), frames
+}
+
+func newNotification(subscription twitch.PayloadSubscription, event string) []byte {
+	raw := json.RawMessage(event)
+
+	data, err := json.Marshal(twitch.NotificationMessage{
+		Metadata: newMetadata("notification"),
+		Payload: struct {
+			Subscription twitch.PayloadSubscription `json:"subscription"`
+			Event        *json.RawMessage           `json:"event"`
+		}{
+			Subscription: subscription,
+			Event:        &raw,
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func newRevocation(subscription twitch.PayloadSubscription, reason twitch.RevocationReason) []byte {
+	subscription.Status = string(reason)
+
+	data, err := json.Marshal(twitch.RevokeMessage{
+		Metadata: newMetadata("revocation"),
+		Payload: struct {
+			Subscription twitch.PayloadSubscription `json:"subscription"`
+		}{
+			Subscription: subscription,
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
 func newMetadata(msgType string) twitch.MessageMetadata {
 	return twitch.MessageMetadata{
 		MessageID:        uuid.NewString(),
@@ -208,7 +362,7 @@ func newClientWithWelcome(t *testing.T, version string, event twitch.EventSubscr
 			AccessToken:     "",
 			VersionOverride: version,
 			Event:           event,
-			Condition:       map[string]string{},
+			Condition:       testCondition(event),
 		}, strings.ReplaceAll(client.Address, "/ws", "/subscriptions"))
 		if err != nil {
 			t.Errorf("could not subscribe: %v", err)
@@ -217,9 +371,132 @@ func newClientWithWelcome(t *testing.T, version string, event twitch.EventSubscr
 	return client
 }
 
+func testCondition(event twitch.EventSubscription) map[string]string {
+	condition := map[string]string{}
+
+	details, ok := twitch.SubscriptionInfo(event)
+	if !ok {
+		return condition
+	}
+
+	for _, key := range details.RequiredConditions {
+		condition[key] = "12345"
+	}
+	if len(details.OneOfConditions) > 0 {
+		condition[details.OneOfConditions[0]] = "12345"
+	}
+	return condition
+}
+
 func connect(t *testing.T, client *twitch.Client) {
 	err := client.Connect()
 	if err != nil {
 		t.Errorf("could not connect client: %v", err)
 	}
 }
+
+type fakeHelixConduits struct {
+	mu       sync.Mutex
+	conduits []twitch.Conduit
+	shards   map[string][]twitch.ConduitShard
+}
+
+func (h *fakeHelixConduits) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.shards == nil {
+		h.shards = map[string][]twitch.ConduitShard{}
+	}
+
+	if strings.HasSuffix(r.URL.Path, "/shards") {
+		h.serveShards(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(twitch.ConduitsResponse{Data: h.conduits})
+	case http.MethodPost:
+		var request twitch.Conduit
+		json.NewDecoder(r.Body).Decode(&request)
+
+		conduit := twitch.Conduit{ID: fmt.Sprintf("conduit-%d", len(h.conduits)+1), ShardCount: request.ShardCount}
+		h.conduits = append(h.conduits, conduit)
+		h.resize(conduit)
+		json.NewEncoder(w).Encode(twitch.ConduitsResponse{Data: []twitch.Conduit{conduit}})
+	case http.MethodPatch:
+		var request twitch.Conduit
+		json.NewDecoder(r.Body).Decode(&request)
+
+		for i, conduit := range h.conduits {
+			if conduit.ID == request.ID {
+				h.conduits[i].ShardCount = request.ShardCount
+				h.resize(h.conduits[i])
+				json.NewEncoder(w).Encode(twitch.ConduitsResponse{Data: []twitch.Conduit{h.conduits[i]}})
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	case http.MethodDelete:
+		for i, conduit := range h.conduits {
+			if conduit.ID == r.URL.Query().Get("id") {
+				h.conduits = append(h.conduits[:i], h.conduits[i+1:]...)
+				delete(h.shards, conduit.ID)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (h *fakeHelixConduits) resize(conduit twitch.Conduit) {
+	shards := h.shards[conduit.ID]
+	for len(shards) < conduit.ShardCount {
+		shards = append(shards, twitch.ConduitShard{ID: fmt.Sprint(len(shards)), Status: "enabled"})
+	}
+	h.shards[conduit.ID] = shards[:conduit.ShardCount]
+}
+
+func (h *fakeHelixConduits) serveShards(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		shards, ok := h.shards[r.URL.Query().Get("conduit_id")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(twitch.ConduitShardsResponse{Data: shards})
+		return
+	}
+
+	var request struct {
+		ConduitID string                `json:"conduit_id"`
+		Shards    []twitch.ConduitShard `json:"shards"`
+	}
+	json.NewDecoder(r.Body).Decode(&request)
+
+	shards, ok := h.shards[request.ConduitID]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var response twitch.UpdateConduitShardsResponse
+	for _, update := range request.Shards {
+		updated := false
+		for i := range shards {
+			if shards[i].ID == update.ID {
+				shards[i].Transport = update.Transport
+				response.Data = append(response.Data, shards[i])
+				updated = true
+			}
+		}
+		if !updated {
+			response.Errors = append(response.Errors, twitch.ConduitShardError{ID: update.ID, Message: "shard not found", Code: "not_found"})
+		}
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(response)
+}
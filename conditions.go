@@ -0,0 +1,233 @@
+package twitch
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrMissingCondition = fmt.Errorf("missing condition")
+	ErrInvalidCondition = fmt.Errorf("invalid condition")
+)
+
+type conditionKind int
+
+const (
+	conditionID conditionKind = iota
+	conditionUserID
+)
+
+// conditionKinds describes the value of every condition key a subscription type can take.
+var conditionKinds = map[string]conditionKind{
+	"broadcaster_user_id":      conditionUserID,
+	"from_broadcaster_user_id": conditionUserID,
+	"to_broadcaster_user_id":   conditionUserID,
+	"moderator_user_id":        conditionUserID,
+	"user_id":                  conditionUserID,
+	"reward_id":                conditionID,
+	"client_id":                conditionID,
+	"conduit_id":               conditionID,
+	"extension_client_id":      conditionID,
+	"organization_id":          conditionID,
+	"category_id":              conditionID,
+	"campaign_id":              conditionID,
+}
+
+// Condition is a typed subscription condition that can be used instead of a condition map.
+type Condition interface {
+	ConditionMap() map[string]string
+}
+
+// ConditionMap allows a plain map to be used where a Condition is expected.
+type ConditionMap map[string]string
+
+func (c ConditionMap) ConditionMap() map[string]string {
+	return c
+}
+
+type BroadcasterCondition struct {
+	BroadcasterUserID string
+}
+
+func (c BroadcasterCondition) ConditionMap() map[string]string {
+	return conditionMap("broadcaster_user_id", c.BroadcasterUserID)
+}
+
+type ModeratorCondition struct {
+	BroadcasterUserID string
+	ModeratorUserID   string
+}
+
+func (c ModeratorCondition) ConditionMap() map[string]string {
+	return conditionMap(
+		"broadcaster_user_id", c.BroadcasterUserID,
+		"moderator_user_id", c.ModeratorUserID,
+	)
+}
+
+type RaidCondition struct {
+	FromBroadcasterUserID string
+	ToBroadcasterUserID   string
+}
+
+func (c RaidCondition) ConditionMap() map[string]string {
+	return conditionMap(
+		"from_broadcaster_user_id", c.FromBroadcasterUserID,
+		"to_broadcaster_user_id", c.ToBroadcasterUserID,
+	)
+}
+
+type ChatCondition struct {
+	BroadcasterUserID string
+	UserID            string
+}
+
+func (c ChatCondition) ConditionMap() map[string]string {
+	return conditionMap(
+		"broadcaster_user_id", c.BroadcasterUserID,
+		"user_id", c.UserID,
+	)
+}
+
+type RewardCondition struct {
+	BroadcasterUserID string
+	RewardID          string
+}
+
+func (c RewardCondition) ConditionMap() map[string]string {
+	return conditionMap(
+		"broadcaster_user_id", c.BroadcasterUserID,
+		"reward_id", c.RewardID,
+	)
+}
+
+type UserCondition struct {
+	UserID string
+}
+
+func (c UserCondition) ConditionMap() map[string]string {
+	return conditionMap("user_id", c.UserID)
+}
+
+type ClientCondition struct {
+	ClientID string
+}
+
+func (c ClientCondition) ConditionMap() map[string]string {
+	return conditionMap("client_id", c.ClientID)
+}
+
+type ConduitCondition struct {
+	ClientID  string
+	ConduitID string
+}
+
+func (c ConduitCondition) ConditionMap() map[string]string {
+	return conditionMap(
+		"client_id", c.ClientID,
+		"conduit_id", c.ConduitID,
+	)
+}
+
+type ExtensionCondition struct {
+	ExtensionClientID string
+}
+
+func (c ExtensionCondition) ConditionMap() map[string]string {
+	return conditionMap("extension_client_id", c.ExtensionClientID)
+}
+
+type DropEntitlementCondition struct {
+	OrganizationID string
+	CategoryID     string
+	CampaignID     string
+}
+
+func (c DropEntitlementCondition) ConditionMap() map[string]string {
+	return conditionMap(
+		"organization_id", c.OrganizationID,
+		"category_id", c.CategoryID,
+		"campaign_id", c.CampaignID,
+	)
+}
+
+// conditionMap builds a condition from key value pairs, leaving out empty values.
+func conditionMap(pairs ...string) map[string]string {
+	condition := map[string]string{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			condition[pairs[i]] = pairs[i+1]
+		}
+	}
+	return condition
+}
+
+// validateCondition checks the condition keys against what the event type expects.
+func validateCondition(event EventSubscription, condition map[string]string) []error {
+	var keys []string
+	for key := range condition {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		if conditionKinds[key] == conditionUserID && condition[key] != "" && !isNumeric(condition[key]) {
+			errs = append(errs, fmt.Errorf("%w: %s must be a numeric user id: got %q", ErrInvalidCondition, key, condition[key]))
+		}
+	}
+
+	metadata, ok := subMetadata[event]
+	if !ok {
+		return errs
+	}
+
+	for _, key := range metadata.RequiredConditions {
+		if condition[key] == "" {
+			errs = append(errs, fmt.Errorf("%w: %s is required for %s", ErrMissingCondition, key, event))
+		}
+	}
+
+	if len(metadata.OneOfConditions) > 0 {
+		var set int
+		for _, key := range metadata.OneOfConditions {
+			if condition[key] != "" {
+				set++
+			}
+		}
+		if set == 0 {
+			errs = append(errs, fmt.Errorf("%w: one of %s is required for %s", ErrMissingCondition, strings.Join(metadata.OneOfConditions, ", "), event))
+		} else if set > 1 {
+			errs = append(errs, fmt.Errorf("%w: only one of %s can be set for %s", ErrInvalidCondition, strings.Join(metadata.OneOfConditions, ", "), event))
+		}
+	}
+
+	for _, key := range keys {
+		if !containsString(metadata.RequiredConditions, key) &&
+			!containsString(metadata.OptionalConditions, key) &&
+			!containsString(metadata.OneOfConditions, key) {
+			errs = append(errs, fmt.Errorf("%w: %s is not supported by %s", ErrInvalidCondition, key, event))
+		}
+	}
+
+	return errs
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func isNumeric(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
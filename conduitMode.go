@@ -0,0 +1,81 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ConduitMode runs a Client as a shard of a conduit. Instead of creating subscriptions,
+// the session of every welcome is registered as the transport of the shard, including
+// the new session after a session_reconnect. The subscriptions are made on the conduit
+// with an app access token and twitch delivers their events to the registered shards.
+type ConduitMode struct {
+	ConduitID string
+	ShardID   string
+
+	ClientID    string
+	AccessToken string
+	Subscriber  *SubscriptionClient
+
+	client *Client
+
+	mu    sync.Mutex
+	shard ConduitShard
+}
+
+// NewConduitMode attaches conduit mode to the client.
+// A client in conduit mode can connect without setting OnWelcome.
+func NewConduitMode(client *Client, conduitID, shardID string) *ConduitMode {
+	mode := &ConduitMode{
+		ConduitID:  conduitID,
+		ShardID:    shardID,
+		Subscriber: NewSubscriptionClient(),
+		client:     client,
+	}
+	client.conduitMode = mode
+	return mode
+}
+
+// Shard returns the shard as it was last registered.
+func (m *ConduitMode) Shard() (ConduitShard, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.shard, m.shard.ID != ""
+}
+
+func (m *ConduitMode) handleWelcome(ctx context.Context, message WelcomeMessage) {
+	err := m.register(ctx, message.Payload.Session.ID)
+	if err != nil {
+		m.client.onError(err)
+	}
+}
+
+// register assigns the session to the shard.
+func (m *ConduitMode) register(ctx context.Context, sessionID string) error {
+	resp, err := m.Subscriber.UpdateConduitShards(ctx, m.ClientID, m.AccessToken, m.ConduitID, []ConduitShard{{
+		ID: m.ShardID,
+		Transport: ShardTransport{
+			Method:    string(TransportWebsocket),
+			SessionID: sessionID,
+		},
+	}})
+	if err != nil {
+		return fmt.Errorf("could not register shard %s of conduit %s: %w", m.ShardID, m.ConduitID, err)
+	}
+
+	for _, shardErr := range resp.Errors {
+		if shardErr.ID == m.ShardID {
+			return fmt.Errorf("could not register shard %s of conduit %s: %s", m.ShardID, m.ConduitID, shardErr.Message)
+		}
+	}
+
+	for _, shard := range resp.Data {
+		if shard.ID == m.ShardID {
+			m.mu.Lock()
+			m.shard = shard
+			m.mu.Unlock()
+		}
+	}
+	return nil
+}
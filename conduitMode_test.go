@@ -0,0 +1,79 @@
+package twitch_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConduitModeRegistersShard(t *testing.T) {
+	t.Parallel()
+
+	reconnectServer, err := newTestServer(keepAliveGen)
+	if err != nil {
+		t.Fatalf("could not create reconnect server: %v", err)
+	}
+	reconnectUrl := fmt.Sprintf("http://%s/%s", reconnectServer.Address, "ws")
+
+	subscriber := newTestConduitClient(t)
+	conduit, err := subscriber.CreateConduit(context.Background(), "client", "token", 1)
+	assert.NoError(t, err)
+
+	client := newClient(t, genReconnectGen(reconnectUrl))
+	mode := twitch.NewConduitMode(client, conduit.ID, "0")
+	mode.Subscriber = subscriber
+
+	firstSession := make(chan string, 1)
+	client.OnWelcome(func(message twitch.WelcomeMessage) {
+		firstSession <- message.Payload.Session.ID
+	})
+	client.OnKeepAlive(func(message twitch.KeepAliveMessage) {
+		client.Close()
+	})
+
+	assert.NoError(t, client.Connect())
+
+	session := <-firstSession
+	assert.Eventually(t, func() bool {
+		shard, ok := mode.Shard()
+		return ok && shard.Transport.SessionID != "" && shard.Transport.SessionID != session
+	}, time.Second, 10*time.Millisecond, "the shard should be registered again with the reconnect session")
+
+	shards, err := subscriber.GetConduitShards(context.Background(), "client", "token", conduit.ID, twitch.ConduitShardFilter{})
+	assert.NoError(t, err)
+	shard, _ := mode.Shard()
+	assert.Equal(t, shard.Transport.SessionID, shards.Data[0].Transport.SessionID)
+}
+
+func TestConduitModeUnknownShard(t *testing.T) {
+	t.Parallel()
+
+	subscriber := newTestConduitClient(t)
+	conduit, err := subscriber.CreateConduit(context.Background(), "client", "token", 1)
+	assert.NoError(t, err)
+
+	client := newClient(t, noDataGen)
+	mode := twitch.NewConduitMode(client, conduit.ID, "5")
+	mode.Subscriber = subscriber
+
+	errs := make(chan error, 1)
+	client.OnError(func(err error) {
+		errs <- err
+		client.Close()
+	})
+
+	go connect(t, client)
+
+	select {
+	case err := <-errs:
+		assert.ErrorContains(t, err, "shard not found")
+	case <-time.After(time.Second):
+		t.Fatal("expected an error registering an unknown shard")
+	}
+	_, ok := mode.Shard()
+	assert.False(t, ok)
+}
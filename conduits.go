@@ -0,0 +1,153 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const twitchConduitUrl = "https://api.twitch.tv/helix/eventsub/conduits"
+
+// Conduit delivers events to a set of shards. It must be managed with an app access token.
+type Conduit struct {
+	ID         string `json:"id"`
+	ShardCount int    `json:"shard_count"`
+}
+
+type ConduitShard struct {
+	ID        string         `json:"id"`
+	Status    string         `json:"status,omitempty"`
+	Transport ShardTransport `json:"transport"`
+}
+
+type ShardTransport struct {
+	Method         string     `json:"method"`
+	Callback       string     `json:"callback,omitempty"`
+	Secret         string     `json:"secret,omitempty"`
+	SessionID      string     `json:"session_id,omitempty"`
+	ConnectedAt    *time.Time `json:"connected_at,omitempty"`
+	DisconnectedAt *time.Time `json:"disconnected_at,omitempty"`
+}
+
+type ConduitsResponse struct {
+	Data []Conduit `json:"data"`
+}
+
+type ConduitShardFilter struct {
+	Status string
+	After  string
+}
+
+type ConduitShardsResponse struct {
+	Data       []ConduitShard `json:"data"`
+	Pagination Pagination     `json:"pagination"`
+}
+
+type ConduitShardError struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
+type UpdateConduitShardsResponse struct {
+	Data   []ConduitShard      `json:"data"`
+	Errors []ConduitShardError `json:"errors"`
+}
+
+func (c *SubscriptionClient) GetConduits(ctx context.Context, clientID, accessToken string) ([]Conduit, error) {
+	var conduits ConduitsResponse
+	err := c.do(ctx, http.MethodGet, c.ConduitUrl, clientID, accessToken, nil, http.StatusOK, &conduits)
+	if err != nil {
+		return nil, fmt.Errorf("could not get conduits: %w", err)
+	}
+	return conduits.Data, nil
+}
+
+func (c *SubscriptionClient) CreateConduit(ctx context.Context, clientID, accessToken string, shardCount int) (Conduit, error) {
+	b, err := json.Marshal(map[string]int{"shard_count": shardCount})
+	if err != nil {
+		return Conduit{}, fmt.Errorf("could not convert request to json: %w", err)
+	}
+
+	var conduits ConduitsResponse
+	err = c.do(ctx, http.MethodPost, c.ConduitUrl, clientID, accessToken, b, http.StatusOK, &conduits)
+	if err != nil {
+		return Conduit{}, fmt.Errorf("could not create conduit: %w", err)
+	}
+	if len(conduits.Data) == 0 {
+		return Conduit{}, fmt.Errorf("could not create conduit: no conduit in response")
+	}
+	return conduits.Data[0], nil
+}
+
+// UpdateConduit changes the shard count of a conduit.
+func (c *SubscriptionClient) UpdateConduit(ctx context.Context, clientID, accessToken, conduitID string, shardCount int) (Conduit, error) {
+	b, err := json.Marshal(map[string]any{"id": conduitID, "shard_count": shardCount})
+	if err != nil {
+		return Conduit{}, fmt.Errorf("could not convert request to json: %w", err)
+	}
+
+	var conduits ConduitsResponse
+	err = c.do(ctx, http.MethodPatch, c.ConduitUrl, clientID, accessToken, b, http.StatusOK, &conduits)
+	if err != nil {
+		return Conduit{}, fmt.Errorf("could not update conduit: %w", err)
+	}
+	if len(conduits.Data) == 0 {
+		return Conduit{}, fmt.Errorf("could not update conduit: no conduit in response")
+	}
+	return conduits.Data[0], nil
+}
+
+func (c *SubscriptionClient) DeleteConduit(ctx context.Context, clientID, accessToken, conduitID string) error {
+	requestUrl := fmt.Sprintf("%s?%s", c.ConduitUrl, url.Values{"id": {conduitID}}.Encode())
+
+	err := c.do(ctx, http.MethodDelete, requestUrl, clientID, accessToken, nil, http.StatusNoContent, nil)
+	if err != nil {
+		return fmt.Errorf("could not delete conduit: %w", err)
+	}
+	return nil
+}
+
+// GetConduitShards lists one page of the shards of a conduit.
+func (c *SubscriptionClient) GetConduitShards(ctx context.Context, clientID, accessToken, conduitID string, filter ConduitShardFilter) (ConduitShardsResponse, error) {
+	query := url.Values{"conduit_id": {conduitID}}
+	if filter.Status != "" {
+		query.Set("status", filter.Status)
+	}
+	if filter.After != "" {
+		query.Set("after", filter.After)
+	}
+	requestUrl := fmt.Sprintf("%s/shards?%s", c.ConduitUrl, query.Encode())
+
+	var shards ConduitShardsResponse
+	err := c.do(ctx, http.MethodGet, requestUrl, clientID, accessToken, nil, http.StatusOK, &shards)
+	if err != nil {
+		return ConduitShardsResponse{}, fmt.Errorf("could not get conduit shards: %w", err)
+	}
+	return shards, nil
+}
+
+// UpdateConduitShards assigns transports to shards of a conduit.
+// Shards that could not be updated are listed in the Errors of the response.
+func (c *SubscriptionClient) UpdateConduitShards(ctx context.Context, clientID, accessToken, conduitID string, shards []ConduitShard) (UpdateConduitShardsResponse, error) {
+	b, err := json.Marshal(struct {
+		ConduitID string         `json:"conduit_id"`
+		Shards    []ConduitShard `json:"shards"`
+	}{
+		ConduitID: conduitID,
+		Shards:    shards,
+	})
+	if err != nil {
+		return UpdateConduitShardsResponse{}, fmt.Errorf("could not convert request to json: %w", err)
+	}
+
+	var response UpdateConduitShardsResponse
+	err = c.do(ctx, http.MethodPatch, fmt.Sprintf("%s/shards", c.ConduitUrl), clientID, accessToken, b, http.StatusAccepted, &response)
+	if err != nil {
+		return UpdateConduitShardsResponse{}, fmt.Errorf("could not update conduit shards: %w", err)
+	}
+	return response, nil
+}
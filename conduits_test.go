@@ -0,0 +1,81 @@
+package twitch_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestConduitClient(t *testing.T) *twitch.SubscriptionClient {
+	server := httptest.NewServer(&fakeHelixConduits{})
+	t.Cleanup(server.Close)
+
+	client := newTestSubscriptionClient(server.URL)
+	client.ConduitUrl = server.URL + "/conduits"
+	return client
+}
+
+func TestConduits(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := newTestConduitClient(t)
+
+	conduit, err := client.CreateConduit(ctx, "client", "token", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, conduit.ShardCount)
+
+	conduit, err = client.UpdateConduit(ctx, "client", "token", conduit.ID, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, conduit.ShardCount)
+
+	conduits, err := client.GetConduits(ctx, "client", "token")
+	assert.NoError(t, err)
+	assert.Equal(t, []twitch.Conduit{conduit}, conduits)
+
+	updated, err := client.UpdateConduitShards(ctx, "client", "token", conduit.ID, []twitch.ConduitShard{
+		{ID: "1", Transport: twitch.ShardTransport{Method: "websocket", SessionID: "session"}},
+		{ID: "9", Transport: twitch.ShardTransport{Method: "websocket", SessionID: "session"}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, updated.Data, 1)
+	if assert.Len(t, updated.Errors, 1) {
+		assert.Equal(t, "9", updated.Errors[0].ID)
+	}
+
+	shards, err := client.GetConduitShards(ctx, "client", "token", conduit.ID, twitch.ConduitShardFilter{})
+	assert.NoError(t, err)
+	if assert.Len(t, shards.Data, 3) {
+		assert.Equal(t, "session", shards.Data[1].Transport.SessionID)
+	}
+
+	assert.NoError(t, client.DeleteConduit(ctx, "client", "token", conduit.ID))
+	assert.Error(t, client.DeleteConduit(ctx, "client", "token", conduit.ID))
+}
+
+func TestSubscribeWithConduitTransport(t *testing.T) {
+	t.Parallel()
+
+	helix := &fakeHelixSubscriptions{maxTotalCost: 10}
+	server := httptest.NewServer(helix)
+	defer server.Close()
+
+	request := twitch.SubscribeRequest{
+		Transport:      twitch.TransportConduit,
+		Event:          twitch.SubUserAuthorizationGrant,
+		TypedCondition: twitch.ClientCondition{ClientID: "client"},
+	}
+
+	_, err := newTestSubscriptionClient(server.URL).Subscribe(context.Background(), request)
+	assert.ErrorContains(t, err, "conduit id is required")
+
+	request.ConduitID = "conduit"
+	resp, err := newTestSubscriptionClient(server.URL).Subscribe(context.Background(), request)
+	assert.NoError(t, err, "app events can use conduits")
+	if assert.Len(t, resp.Data, 1) {
+		assert.Equal(t, twitch.SubscriptionTransport{Method: "conduit", ConduitID: "conduit"}, resp.Data[0].Transport)
+	}
+}
@@ -0,0 +1,119 @@
+package twitch_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/joeyak/go-twitch-eventsub/v3/eventsubtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// documentedPayload is an example notification from the twitch docs. Drop entitlements
+// are documented with an events list instead of an event.
+type documentedPayload struct {
+	Subscription twitch.PayloadSubscription `json:"subscription"`
+	Event        json.RawMessage            `json:"event"`
+	Events       json.RawMessage            `json:"events"`
+}
+
+// documentedMismatches lists the fields of documented examples that disagree with the field
+// reference of the same docs page. The event types follow the reference.
+var documentedMismatches = map[string][]string{
+	// The example names resub.sub_tier as sub_plan
+	"channel.chat.notification.json": {"resub.sub_plan"},
+}
+
+func readDocumentedPayloads(t *testing.T) map[string]documentedPayload {
+	files, err := filepath.Glob(filepath.Join("testdata", "schema", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payloads := map[string]documentedPayload{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var payload documentedPayload
+		err = json.Unmarshal(data, &payload)
+		if err != nil {
+			t.Fatalf("could not unmarshal %s: %v", file, err)
+		}
+		payloads[filepath.Base(file)] = payload
+	}
+	return payloads
+}
+
+// TestSchemaConformance strictly decodes the documented example of every event so fields
+// twitch adds or renames show up as unknown fields.
+func TestSchemaConformance(t *testing.T) {
+	t.Parallel()
+
+	for name, payload := range readDocumentedPayloads(t) {
+		name, payload := name, payload
+		t.Run(strings.TrimSuffix(name, ".json"), func(t *testing.T) {
+			t.Parallel()
+
+			event := payload.Subscription.Type
+			data := payload.Event
+			if len(payload.Events) > 0 {
+				data = payload.Events
+			}
+
+			newEvent, ok := twitch.NewEvent(event)
+			if !assert.True(t, ok, "no event type for %s", event) {
+				return
+			}
+
+			unknown := twitch.UnknownFields(event, data)
+			assert.Equal(t, documentedMismatches[name], unknown, "fields missing from %T", newEvent)
+			if len(unknown) > 0 {
+				return
+			}
+
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			decoder.DisallowUnknownFields()
+			err := decoder.Decode(newEvent)
+			assert.NoError(t, err, "%T does not model every field of the documented example", newEvent)
+		})
+	}
+}
+
+// TestSchemaCoverage reports the subscription types without a documented example in testdata/schema
+// or a sample in eventsubtest. Every type must have a sample.
+func TestSchemaCoverage(t *testing.T) {
+	t.Parallel()
+
+	documented := map[twitch.EventSubscription]bool{}
+	for _, payload := range readDocumentedPayloads(t) {
+		documented[payload.Subscription.Type] = true
+	}
+
+	var withoutExample, withoutSample []string
+	for _, event := range twitch.SubscriptionTypes() {
+		if !documented[event] {
+			withoutExample = append(withoutExample, string(event))
+		}
+		if len(eventsubtest.SampleVariants(event)) == 0 {
+			withoutSample = append(withoutSample, string(event))
+		}
+	}
+	sort.Strings(withoutExample)
+	sort.Strings(withoutSample)
+
+	for _, event := range withoutExample {
+		t.Logf("no documented example: %s", event)
+	}
+	for _, event := range withoutSample {
+		t.Logf("no sample: %s", event)
+	}
+	assert.Empty(t, withoutSample, "subscription types without samples")
+}
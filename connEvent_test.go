@@ -14,6 +14,20 @@ func assertSpecificEventOccured(t *testing.T, register func(client *twitch.Clien
 	})
 }
 
+// assertUnsubscribedEventOccured sends the event right after the welcome without subscribing, for
+// events that can't be subscribed to over a websocket.
+func assertUnsubscribedEventOccured(t *testing.T, register func(client *twitch.Client, ch chan struct{}), event twitch.EventSubscription, suffixes ...string) {
+	assertEventOccured(t, func(ch chan struct{}) {
+		gen := getTestEventData(event, suffixes...)
+		client := newClient(t, func() ([][]byte, bool, error) {
+			data, _, err := gen()
+			return data, false, err
+		})
+		register(client, ch)
+		go connect(t, client)
+	})
+}
+
 func TestNotification(t *testing.T) {
 	t.Parallel()
 
@@ -27,7 +41,7 @@ func TestNotification(t *testing.T) {
 func TestUnkownSubscription(t *testing.T) {
 	t.Parallel()
 
-	assertSpecificEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
+	assertUnsubscribedEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
 		client.OnError(func(err error) {
 			close(ch)
 		})
@@ -337,7 +351,7 @@ func TestEventChannelPredictionEnd(t *testing.T) {
 func TestEventDropEntitlementGrant(t *testing.T) {
 	t.Parallel()
 
-	assertSpecificEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
+	assertUnsubscribedEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
 		client.OnEventDropEntitlementGrant(func(event []twitch.EventDropEntitlementGrant) {
 			close(ch)
 		})
@@ -347,7 +361,7 @@ func TestEventDropEntitlementGrant(t *testing.T) {
 func TestEventExtensionBitsTransactionCreate(t *testing.T) {
 	t.Parallel()
 
-	assertSpecificEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
+	assertUnsubscribedEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
 		client.OnEventExtensionBitsTransactionCreate(func(event twitch.EventExtensionBitsTransactionCreate) {
 			close(ch)
 		})
@@ -437,7 +451,7 @@ func TestEventStreamOffline(t *testing.T) {
 func TestEventUserAuthorizationGrant(t *testing.T) {
 	t.Parallel()
 
-	assertSpecificEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
+	assertUnsubscribedEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
 		client.OnEventUserAuthorizationGrant(func(event twitch.EventUserAuthorizationGrant) {
 			close(ch)
 		})
@@ -447,7 +461,7 @@ func TestEventUserAuthorizationGrant(t *testing.T) {
 func TestEventUserAuthorizationRevoke(t *testing.T) {
 	t.Parallel()
 
-	assertSpecificEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
+	assertUnsubscribedEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
 		client.OnEventUserAuthorizationRevoke(func(event twitch.EventUserAuthorizationRevoke) {
 			close(ch)
 		})
@@ -457,7 +471,7 @@ func TestEventUserAuthorizationRevoke(t *testing.T) {
 func TestEventUserAuthorizationRevokeNoUser(t *testing.T) {
 	t.Parallel()
 
-	assertSpecificEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
+	assertUnsubscribedEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
 		client.OnEventUserAuthorizationRevoke(func(event twitch.EventUserAuthorizationRevoke) {
 			close(ch)
 		})
@@ -684,6 +698,18 @@ func TestEventChannelChatNotification(t *testing.T) {
 	}, twitch.SubChannelChatNotification)
 }
 
+func TestEventChannelChatNotificationRaid(t *testing.T) {
+	t.Parallel()
+
+	assertSpecificEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
+		client.OnEventChannelChatNotification(func(event twitch.EventChannelChatNotification) {
+			if event.Raid != nil && event.Raid.ViewerCount == 42 {
+				close(ch)
+			}
+		})
+	}, twitch.SubChannelChatNotification, "raid")
+}
+
 func TestEventChannelChatSettingsUpdate(t *testing.T) {
 	t.Parallel()
 
@@ -807,7 +833,7 @@ func TestEventChannelUnbanRequestResolve(t *testing.T) {
 func TestEventConduitShardDisabled(t *testing.T) {
 	t.Parallel()
 
-	assertSpecificEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
+	assertUnsubscribedEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
 		client.OnEventConduitShardDisabled(func(event twitch.EventConduitShardDisabled) {
 			close(ch)
 		})
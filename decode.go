@@ -0,0 +1,358 @@
+package twitch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DecodingMode decides what happens to event fields that the event type does not model.
+type DecodingMode int
+
+const (
+	// DecodeLenient drops fields that are not modeled.
+	DecodeLenient DecodingMode = iota
+	// DecodeStrict fails the event with an UnknownFieldsError.
+	DecodeStrict
+	// DecodeCapture keeps the fields that are not modeled in the Extra map of the event.
+	DecodeCapture
+)
+
+var (
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	extraType       = reflect.TypeOf(map[string]json.RawMessage{})
+)
+
+// DecodeError is returned when a frame or event does not match the type it is decoded into.
+type DecodeError struct {
+	// Field is the json path of the field that did not match, empty when the value itself did not match.
+	Field string
+	// Value is the kind of json value found, e.g. number. It is empty when the json is malformed.
+	Value string
+	// Type is the Go type that was expected.
+	Type string
+	// Offset is the byte offset in the json where decoding failed, 0 when it is not known.
+	Offset int64
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("malformed json at offset %d: %v", e.Offset, e.Err)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(e.Err, &typeErr) {
+		return fmt.Sprintf("field %s could not be decoded into %s: %v", e.fieldName(), e.Type, e.Err)
+	}
+	return fmt.Sprintf("field %s is a %s, expected %s", e.fieldName(), e.Value, e.Type)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+func (e *DecodeError) fieldName() string {
+	if e.Field == "" {
+		return "<root>"
+	}
+	return e.Field
+}
+
+// UnknownFieldsError is returned in DecodeStrict mode when an event has fields that its type does not model.
+type UnknownFieldsError struct {
+	Event  EventSubscription
+	Fields []string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	return fmt.Sprintf("%s has unknown fields %s", e.Event, strings.Join(e.Fields, ", "))
+}
+
+// decodeEvent decodes an event and handles the fields its type does not model with the decoding mode.
+func (h *handlers) decodeEvent(event EventSubscription, data []byte, v any) error {
+	err := decodeJSON(data, v)
+	if err != nil {
+		return err
+	}
+	if h.decodingMode == DecodeLenient && h.onUnknownField == nil {
+		return nil
+	}
+
+	unknown := map[string]json.RawMessage{}
+	unknownFields(data, reflect.TypeOf(v), "", unknown)
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	fields := make([]string, 0, len(unknown))
+	for field := range unknown {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	h.warnUnknownFields(event, fields, unknown)
+
+	switch h.decodingMode {
+	case DecodeStrict:
+		return &UnknownFieldsError{Event: event, Fields: fields}
+	case DecodeCapture:
+		captureExtra(reflect.ValueOf(v).Elem(), data)
+	}
+	return nil
+}
+
+// captureExtra sets the Extra map of the event, or of every event in a list of them.
+func captureExtra(value reflect.Value, data []byte) {
+	switch value.Kind() {
+	case reflect.Slice:
+		var items []json.RawMessage
+		if json.Unmarshal(data, &items) != nil || len(items) != value.Len() {
+			return
+		}
+		for i, item := range items {
+			captureExtra(value.Index(i), item)
+		}
+	case reflect.Struct:
+		extra := value.FieldByName("Extra")
+		if !extra.IsValid() || !extra.CanSet() || extra.Type() != extraType {
+			return
+		}
+
+		unknown := map[string]json.RawMessage{}
+		unknownFields(data, value.Type(), "", unknown)
+		if len(unknown) > 0 {
+			extra.Set(reflect.ValueOf(unknown))
+		}
+	}
+}
+
+// warnUnknownFields calls OnUnknownField for the fields that were not seen before in the event type.
+func (h *handlers) warnUnknownFields(event EventSubscription, fields []string, unknown map[string]json.RawMessage) {
+	if h.onUnknownField == nil {
+		return
+	}
+
+	h.unknownMu.Lock()
+	if h.unknownSeen == nil {
+		h.unknownSeen = map[string]bool{}
+	}
+	var unseen []string
+	for _, field := range fields {
+		key := fmt.Sprintf("%s %s", event, field)
+		if !h.unknownSeen[key] {
+			h.unknownSeen[key] = true
+			unseen = append(unseen, field)
+		}
+	}
+	h.unknownMu.Unlock()
+
+	for _, field := range unseen {
+		h.onUnknownField(event, field, unknown[field])
+	}
+}
+
+// decodeJSON unmarshals data into v and turns json errors into a DecodeError.
+func decodeJSON(data []byte, v any) error {
+	err := json.Unmarshal(data, v)
+	if err == nil {
+		return nil
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &typeErr):
+		return &DecodeError{
+			Field:  typeErr.Field,
+			Value:  typeErr.Value,
+			Type:   typeErr.Type.String(),
+			Offset: typeErr.Offset,
+			Err:    err,
+		}
+	case errors.As(err, &syntaxErr):
+		return &DecodeError{
+			Offset: syntaxErr.Offset,
+			Err:    err,
+		}
+	}
+
+	// Errors from types such as time.Time do not say which field failed, so it is searched for
+	field, raw, t := locateError(data, reflect.TypeOf(v), "")
+	return &DecodeError{
+		Field: field,
+		Value: jsonKind(raw),
+		Type:  t.String(),
+		Err:   err,
+	}
+}
+
+// locateError finds the deepest field of data that does not decode into t.
+func locateError(data []byte, t reflect.Type, path string) (string, []byte, reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		return path, data, t
+	}
+
+	var children map[string]json.RawMessage
+	var childType func(key string) (reflect.Type, bool)
+
+	switch t.Kind() {
+	case reflect.Struct:
+		fields := jsonFields(t)
+		childType = func(key string) (reflect.Type, bool) {
+			return findField(fields, key)
+		}
+	case reflect.Map:
+		childType = func(key string) (reflect.Type, bool) {
+			return t.Elem(), true
+		}
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if json.Unmarshal(data, &items) != nil {
+			return path, data, t
+		}
+		for _, item := range items {
+			if json.Unmarshal(item, reflect.New(t.Elem()).Interface()) != nil {
+				return locateError(item, t.Elem(), path)
+			}
+		}
+		return path, data, t
+	default:
+		return path, data, t
+	}
+
+	if json.Unmarshal(data, &children) != nil {
+		return path, data, t
+	}
+	for key, child := range children {
+		fieldType, ok := childType(key)
+		if !ok {
+			continue
+		}
+		if json.Unmarshal(child, reflect.New(fieldType).Interface()) != nil {
+			return locateError(child, fieldType, joinPath(path, key))
+		}
+	}
+	return path, data, t
+}
+
+// jsonFields maps the json names of the fields of a struct, including embedded structs, to their types.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for embeddedName, embeddedType := range jsonFields(field.Type) {
+				if _, ok := fields[embeddedName]; !ok {
+					fields[embeddedName] = embeddedType
+				}
+			}
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// findField matches the key like encoding/json, preferring an exact match over a case insensitive one.
+func findField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if field, ok := fields[key]; ok {
+		return field, true
+	}
+	for name, field := range fields {
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+	return nil, false
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func jsonKind(data []byte) string {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return "value"
+	}
+
+	switch data[0] {
+	case '"':
+		return "string"
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case 't', 'f':
+		return "bool"
+	case 'n':
+		return "null"
+	}
+	return "number"
+}
+
+// unknownFields adds the json values in data that have no field in t to unknown, keyed by their path.
+func unknownFields(data []byte, t reflect.Type, path string, unknown map[string]json.RawMessage) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var children map[string]json.RawMessage
+		if json.Unmarshal(data, &children) != nil {
+			return
+		}
+
+		fields := jsonFields(t)
+		for key, child := range children {
+			fieldType, ok := findField(fields, key)
+			if !ok {
+				if _, ok := unknown[joinPath(path, key)]; !ok {
+					unknown[joinPath(path, key)] = child
+				}
+				continue
+			}
+			unknownFields(child, fieldType, joinPath(path, key), unknown)
+		}
+	case reflect.Map:
+		var children map[string]json.RawMessage
+		if json.Unmarshal(data, &children) != nil {
+			return
+		}
+		for key, child := range children {
+			unknownFields(child, t.Elem(), joinPath(path, key), unknown)
+		}
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if json.Unmarshal(data, &items) != nil {
+			return
+		}
+		for _, item := range items {
+			unknownFields(item, t.Elem(), path, unknown)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package twitch_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/joeyak/go-twitch-eventsub/v3/eventsubtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRaidNotification returns a chat notification frame with a field added at the top and in the raid.
+func newRaidNotification(t *testing.T) []byte {
+	data, err := eventsubtest.SampleEvent(twitch.SubChannelChatNotification, "raid")
+	assert.NoError(t, err)
+
+	var event map[string]any
+	assert.NoError(t, json.Unmarshal(data, &event))
+	event["new_field"] = "new"
+	event["raid"].(map[string]any)["new_nested"] = 1
+
+	frame, err := eventsubtest.Notification(twitch.SubChannelChatNotification, event)
+	assert.NoError(t, err)
+	return frame
+}
+
+func decodeWithMode(t *testing.T, mode twitch.DecodingMode, frame []byte) (*twitch.EventChannelChatNotification, []error) {
+	source := frameSource{frame}
+	client := twitch.NewClient()
+	client.SetFrameSource(&source)
+	client.SetDecodingMode(mode)
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+
+	var errs []error
+	client.OnError(func(err error) {
+		errs = append(errs, err)
+	})
+
+	events := make(chan twitch.EventChannelChatNotification, 1)
+	client.OnEventChannelChatNotification(func(event twitch.EventChannelChatNotification) {
+		events <- event
+	})
+
+	assert.NoError(t, client.Connect())
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	select {
+	case event := <-events:
+		return &event, nil
+	case <-time.After(time.Second):
+		t.Fatal("event was not handled")
+		return nil, nil
+	}
+}
+
+func TestDecodeLenient(t *testing.T) {
+	t.Parallel()
+
+	event, errs := decodeWithMode(t, twitch.DecodeLenient, newRaidNotification(t))
+	assert.Empty(t, errs)
+	if assert.NotNil(t, event) {
+		assert.Equal(t, 42, event.Raid.ViewerCount)
+		assert.Nil(t, event.Extra)
+	}
+}
+
+func TestDecodeStrict(t *testing.T) {
+	t.Parallel()
+
+	_, errs := decodeWithMode(t, twitch.DecodeStrict, newRaidNotification(t))
+	if assert.Len(t, errs, 1) {
+		var unknownErr *twitch.UnknownFieldsError
+		if assert.ErrorAs(t, errs[0], &unknownErr) {
+			assert.Equal(t, twitch.SubChannelChatNotification, unknownErr.Event)
+			assert.Equal(t, []string{"new_field", "raid.new_nested"}, unknownErr.Fields)
+		}
+	}
+
+	frame, err := eventsubtest.SampleNotification(twitch.SubChannelChatNotification, "raid")
+	assert.NoError(t, err)
+	_, errs = decodeWithMode(t, twitch.DecodeStrict, frame)
+	assert.Empty(t, errs, "events without unknown fields decode in strict mode")
+}
+
+func TestDecodeCapture(t *testing.T) {
+	t.Parallel()
+
+	event, errs := decodeWithMode(t, twitch.DecodeCapture, newRaidNotification(t))
+	assert.Empty(t, errs)
+	if assert.NotNil(t, event) {
+		assert.Equal(t, 42, event.Raid.ViewerCount)
+		assert.Equal(t, map[string]json.RawMessage{
+			"new_field":       json.RawMessage(`"new"`),
+			"raid.new_nested": json.RawMessage(`1`),
+		}, event.Extra)
+	}
+}
+
+func TestOnUnknownField(t *testing.T) {
+	t.Parallel()
+
+	follow, err := eventsubtest.SampleEvent(twitch.SubChannelFollow)
+	assert.NoError(t, err)
+	var event map[string]any
+	assert.NoError(t, json.Unmarshal(follow, &event))
+	event["new_field"] = true
+	followFrame, err := eventsubtest.Notification(twitch.SubChannelFollow, event)
+	assert.NoError(t, err)
+
+	source := frameSource{newRaidNotification(t), newRaidNotification(t), followFrame}
+	client := twitch.NewClient()
+	client.SetFrameSource(&source)
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+
+	var mu sync.Mutex
+	var warnings []string
+	client.OnUnknownField(func(event twitch.EventSubscription, field string, value json.RawMessage) {
+		mu.Lock()
+		defer mu.Unlock()
+		warnings = append(warnings, string(event)+" "+field)
+	})
+
+	assert.NoError(t, client.Connect())
+	assert.Equal(t, []string{
+		"channel.chat.notification new_field",
+		"channel.chat.notification raid.new_nested",
+		"channel.follow new_field",
+	}, warnings, "each field warns once per event type")
+}
+
+func TestEventsHaveExtra(t *testing.T) {
+	t.Parallel()
+
+	for _, event := range twitch.SubscriptionTypes() {
+		newEvent, ok := twitch.NewEvent(event)
+		if !assert.True(t, ok, "no event type for %s", event) {
+			continue
+		}
+
+		eventType := reflect.TypeOf(newEvent).Elem()
+		if eventType.Kind() == reflect.Slice {
+			eventType = eventType.Elem()
+		}
+
+		field, ok := eventType.FieldByName("Extra")
+		if assert.True(t, ok, "%T has no Extra", newEvent) {
+			assert.Equal(t, reflect.TypeOf(map[string]json.RawMessage{}), field.Type)
+		}
+	}
+}
@@ -1,10 +1,17 @@
 package twitch
 
 import (
+	"encoding/json"
 	"math"
 	"time"
 )
 
+// Unmodeled holds the fields of an event that its type does not model.
+// Extra is only filled when decoding with DecodeCapture.
+type Unmodeled struct {
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
 type User struct {
 	UserID    string `json:"user_id"`
 	UserLogin string `json:"user_login"`
@@ -109,6 +116,7 @@ type Warning struct {
 
 type EventChannelUpdate struct {
 	Broadcaster
+	Unmodeled
 
 	Title                       string   `json:"title"`
 	Language                    string   `json:"language"`
@@ -120,6 +128,7 @@ type EventChannelUpdate struct {
 type EventChannelFollow struct {
 	User
 	Broadcaster
+	Unmodeled
 
 	FollowedAt time.Time `json:"followed_at"`
 }
@@ -127,6 +136,7 @@ type EventChannelFollow struct {
 type EventChannelSubscribe struct {
 	User
 	Broadcaster
+	Unmodeled
 
 	Tier   string `json:"tier"`
 	IsGift bool   `json:"is_gift"`
@@ -135,6 +145,7 @@ type EventChannelSubscribe struct {
 type EventChannelSubscriptionEnd struct {
 	User
 	Broadcaster
+	Unmodeled
 
 	Tier   string `json:"tier"`
 	IsGift bool   `json:"is_gift"`
@@ -143,6 +154,7 @@ type EventChannelSubscriptionEnd struct {
 type EventChannelSubscriptionGift struct {
 	User
 	Broadcaster
+	Unmodeled
 
 	Total           int    `json:"total"`
 	Tier            string `json:"tier"`
@@ -164,6 +176,7 @@ type Message struct {
 type EventChannelSubscriptionMessage struct {
 	User
 	Broadcaster
+	Unmodeled
 
 	Tier             string  `json:"tier"`
 	Message          Message `json:"message"`
@@ -175,6 +188,7 @@ type EventChannelSubscriptionMessage struct {
 type EventChannelCheer struct {
 	User
 	Broadcaster
+	Unmodeled
 
 	Message     string `json:"message"`
 	Bits        int    `json:"bits"`
@@ -184,6 +198,7 @@ type EventChannelCheer struct {
 type EventChannelRaid struct {
 	FromBroadcaster
 	ToBroadcaster
+	Unmodeled
 
 	Viewers int `json:"viewers"`
 }
@@ -192,6 +207,7 @@ type EventChannelBan struct {
 	User
 	Broadcaster
 	Moderator
+	Unmodeled
 
 	Reason      string     `json:"reason"`
 	BannedAt    time.Time  `json:"banned_at"`
@@ -203,26 +219,31 @@ type EventChannelUnban struct {
 	User
 	Broadcaster
 	Moderator
+	Unmodeled
 }
 
 type EventChannelModeratorAdd struct {
 	Broadcaster
 	User
+	Unmodeled
 }
 
 type EventChannelModeratorRemove struct {
 	Broadcaster
 	User
+	Unmodeled
 }
 
 type EventChannelVIPAdd struct {
 	Broadcaster
 	User
+	Unmodeled
 }
 
 type EventChannelVIPRemove struct {
 	Broadcaster
 	User
+	Unmodeled
 }
 
 type MaxChannelPointsPerStream struct {
@@ -243,6 +264,7 @@ type GlobalCooldown struct {
 
 type EventChannelChannelPointsCustomRewardAdd struct {
 	Broadcaster
+	Unmodeled
 
 	ID                                string                    `json:"id"`
 	IsEnabled                         bool                      `json:"is_enabled"`
@@ -277,6 +299,7 @@ type CustomChannelPointReward struct {
 type EventChannelChannelPointsCustomRewardRedemptionAdd struct {
 	Broadcaster
 	User
+	Unmodeled
 
 	ID         string                   `json:"id"`
 	UserInput  string                   `json:"user_input"`
@@ -301,6 +324,7 @@ type AutomaticChannelPointReward struct {
 type EventChannelChannelPointsAutomaticRewardRedemptionAdd struct {
 	Broadcaster
 	User
+	Unmodeled
 
 	ID         string                      `json:"id"`
 	Reward     AutomaticChannelPointReward `json:"reward"`
@@ -324,6 +348,7 @@ type PollVoting struct {
 
 type EventChannelPollBegin struct {
 	Broadcaster
+	Unmodeled
 
 	ID                  string       `json:"id"`
 	Title               string       `json:"title"`
@@ -339,7 +364,8 @@ type EventChannelPollProgress EventChannelPollBegin
 type EventChannelPollEnd struct {
 	EventChannelPollBegin
 
-	Status string `json:"status"`
+	Status  string    `json:"status"`
+	EndedAt time.Time `json:"ended_at"`
 }
 
 type TopPredictor struct {
@@ -360,12 +386,16 @@ type PredictionOutcome struct {
 
 type EventChannelPredictionBegin struct {
 	Broadcaster
+	Unmodeled
 
 	ID        string              `json:"id"`
 	Title     string              `json:"title"`
 	Outcomes  []PredictionOutcome `json:"outcomes"`
 	StartedAt time.Time           `json:"started_at"`
 	LocksAt   time.Time           `json:"locks_at"`
+
+	// LockedAt is only sent with lock events.
+	LockedAt time.Time `json:"locked_at"`
 }
 
 type EventChannelPredictionProgress EventChannelPredictionBegin
@@ -374,6 +404,7 @@ type EventChannelPredictionLock EventChannelPredictionBegin
 
 type EventChannelPredictionEnd struct {
 	Broadcaster
+	Unmodeled
 
 	ID               string              `json:"id"`
 	Title            string              `json:"title"`
@@ -397,6 +428,8 @@ type DropEntitlement struct {
 }
 
 type EventDropEntitlementGrant struct {
+	Unmodeled
+
 	ID   string          `json:"id"`
 	Data DropEntitlement `json:"data"`
 }
@@ -411,6 +444,7 @@ type ExtensionProduct struct {
 type EventExtensionBitsTransactionCreate struct {
 	Broadcaster
 	User
+	Unmodeled
 
 	ID                string           `json:"id"`
 	ExtensionClientID string           `json:"extension_client_id"`
@@ -419,6 +453,7 @@ type EventExtensionBitsTransactionCreate struct {
 
 type EventChannelGoalBegin struct {
 	Broadcaster
+	Unmodeled
 
 	ID            string    `json:"id"`
 	Type          string    `json:"type"`
@@ -446,16 +481,18 @@ type HypeTrainContribution struct {
 
 type EventChannelHypeTrainBegin struct {
 	Broadcaster
+	Unmodeled
 
-	Id               string                  `json:"id"`
-	Total            int                     `json:"total"`
-	Progress         int                     `json:"progress"`
-	Goal             int                     `json:"goal"`
-	TopContributions []HypeTrainContribution `json:"top_contributions"`
-	LastContribution HypeTrainContribution   `json:"last_contribution"`
-	Level            int                     `json:"level"`
-	StartedAt        time.Time               `json:"started_at"`
-	ExpiresAt        time.Time               `json:"expires_at"`
+	Id                 string                  `json:"id"`
+	Total              int                     `json:"total"`
+	Progress           int                     `json:"progress"`
+	Goal               int                     `json:"goal"`
+	TopContributions   []HypeTrainContribution `json:"top_contributions"`
+	LastContribution   HypeTrainContribution   `json:"last_contribution"`
+	Level              int                     `json:"level"`
+	StartedAt          time.Time               `json:"started_at"`
+	ExpiresAt          time.Time               `json:"expires_at"`
+	IsGoldenKappaTrain bool                    `json:"is_golden_kappa_train"`
 }
 
 type EventChannelHypeTrainProgress struct {
@@ -466,28 +503,38 @@ type EventChannelHypeTrainProgress struct {
 
 type EventChannelHypeTrainEnd struct {
 	Broadcaster
+	Unmodeled
 
 	Id               string                  `json:"id"`
 	Level            int                     `json:"level"`
 	Total            int                     `json:"total"`
 	TopContributions []HypeTrainContribution `json:"top_contributions"`
 	StartedAt        time.Time               `json:"started_at"`
-	ExpiresAt        time.Time               `json:"expires_at"`
+	EndedAt          time.Time               `json:"ended_at"`
 	CooldownEndsAt   time.Time               `json:"cooldown_ends_at"`
+
+	// Deprecated: twitch does not send expires_at for an ended train, use EndedAt.
+	ExpiresAt          time.Time `json:"expires_at"`
+	IsGoldenKappaTrain bool      `json:"is_golden_kappa_train"`
 }
 
 type EventStreamOnline struct {
 	Broadcaster
+	Unmodeled
 
 	Id        string    `json:"id"`
 	Type      string    `json:"type"`
 	StartedAt time.Time `json:"started_at"`
 }
 
-type EventStreamOffline Broadcaster
+type EventStreamOffline struct {
+	Broadcaster
+	Unmodeled
+}
 
 type EventUserAuthorizationGrant struct {
 	User
+	Unmodeled
 
 	ClientID string `json:"client_id"`
 }
@@ -496,6 +543,7 @@ type EventUserAuthorizationRevoke EventUserAuthorizationGrant
 
 type EventUserUpdate struct {
 	User
+	Unmodeled
 
 	Email         string `json:"email"`
 	EmailVerified bool   `json:"email_verified"`
@@ -515,7 +563,10 @@ func (a GoalAmount) Amount() float64 {
 type BaseCharity struct {
 	Broadcaster
 	User
+	Unmodeled
 
+	// ID is the donation for donate events and the campaign for the other charity events.
+	ID                 string `json:"id"`
 	CampaignID         string `json:"campaign_id"`
 	CharityName        string `json:"charity_name"`
 	CharityDescription string `json:"charity_description"`
@@ -529,8 +580,16 @@ type EventChannelCharityCampaignDonate struct {
 	Amount GoalAmount `json:"amount"`
 }
 
+// CharityBroadcaster is how the charity campaign events other than donate name the broadcaster.
+type CharityBroadcaster struct {
+	BroadcasterID    string `json:"broadcaster_id"`
+	BroadcasterLogin string `json:"broadcaster_login"`
+	BroadcasterName  string `json:"broadcaster_name"`
+}
+
 type EventChannelCharityCampaignProgress struct {
 	BaseCharity
+	CharityBroadcaster
 
 	CurrentAmount GoalAmount `json:"current_amount"`
 	TargetAmount  GoalAmount `json:"target_amount"`
@@ -551,6 +610,7 @@ type EventChannelCharityCampaignStop struct {
 type EventChannelShieldModeBegin struct {
 	Broadcaster
 	Moderator
+	Unmodeled
 
 	StartedAt time.Time `json:"started_at"`
 }
@@ -558,7 +618,11 @@ type EventChannelShieldModeBegin struct {
 type EventChannelShieldModeEnd struct {
 	Broadcaster
 	Moderator
+	Unmodeled
+
+	EndedAt time.Time `json:"ended_at"`
 
+	// Deprecated: twitch sends ended_at, use EndedAt.
 	StoppedAt time.Time `json:"stopped_at"`
 }
 
@@ -566,6 +630,7 @@ type EventChannelShoutoutCreate struct {
 	Broadcaster
 	Moderator
 	ToBroadcaster
+	Unmodeled
 
 	ViewerCount          int       `json:"viewer_count"`
 	StartedAt            time.Time `json:"started_at"`
@@ -576,6 +641,7 @@ type EventChannelShoutoutCreate struct {
 type EventChannelShoutoutReceive struct {
 	Broadcaster
 	FromBroadcaster
+	Unmodeled
 
 	ViewerCount int       `json:"viewer_count"`
 	StartedAt   time.Time `json:"started_at"`
@@ -583,6 +649,7 @@ type EventChannelShoutoutReceive struct {
 
 type EventChannelAdBreakBegin struct {
 	Broadcaster
+	Unmodeled
 
 	DurationSeconds    int       `json:"duration_seconds"`
 	StartedAt          time.Time `json:"started_at"`
@@ -595,12 +662,14 @@ type EventChannelAdBreakBegin struct {
 type EventChannelWarningAcknowledge struct {
 	Broadcaster
 	User
+	Unmodeled
 }
 
 type EventChannelWarningSend struct {
 	Broadcaster
 	Moderator
 	User
+	Unmodeled
 
 	Reason         string   `json:"reason"`
 	ChatRulesCited []string `json:"chat_rules_cited"`
@@ -609,6 +678,7 @@ type EventChannelWarningSend struct {
 type EventChannelUnbanRequestCreate struct {
 	Broadcaster
 	User
+	Unmodeled
 
 	Id        string    `json:"id"`
 	Text      string    `json:"text"`
@@ -619,6 +689,7 @@ type EventChannelUnbanRequestResolve struct {
 	Broadcaster
 	Moderator
 	User
+	Unmodeled
 
 	Id             string `json:"id"`
 	ResolutionText string `json:"resolution_text"`
@@ -628,6 +699,7 @@ type EventChannelUnbanRequestResolve struct {
 type EventChannelSharedChatBegin struct {
 	Broadcaster
 	HostBroadcaster
+	Unmodeled
 
 	SessionId    string        `json:"session_id"`
 	Participants []Broadcaster `json:"participants"`
@@ -638,6 +710,7 @@ type EventChannelSharedChatUpdate EventChannelSharedChatBegin
 type EventChannelSharedChatEnd struct {
 	Broadcaster
 	HostBroadcaster
+	Unmodeled
 
 	SessionId string `json:"session_id"`
 }
@@ -647,6 +720,8 @@ type UserWhisper struct {
 }
 
 type EventUserWhisperMessage struct {
+	Unmodeled
+
 	FromUserId    string      `json:"from_user_id"`
 	FromUserLogin string      `json:"from_user_login"`
 	FromUserName  string      `json:"from_user_name"`
@@ -661,6 +736,7 @@ type EventChannelModerate struct {
 	Broadcaster
 	SourceBroadcaster
 	Moderator
+	Unmodeled
 
 	Action              string          `json:"action"`
 	Followers           *Followers      `json:"followers,omitempty"`
@@ -717,6 +793,7 @@ type ChatMessage struct {
 type EventAutomodMessageHold struct {
 	Broadcaster
 	User
+	Unmodeled
 
 	MessageId string      `json:"message_id"`
 	Message   ChatMessage `json:"message"`
@@ -729,6 +806,7 @@ type EventAutomodMessageUpdate struct {
 	Broadcaster
 	User
 	Moderator
+	Unmodeled
 
 	MessageId string      `json:"message_id"`
 	Message   ChatMessage `json:"message"`
@@ -741,6 +819,7 @@ type EventAutomodMessageUpdate struct {
 type EventAutomodSettingsUpdate struct {
 	Broadcaster
 	Moderator
+	Unmodeled
 
 	OverallLevel            *int `json:"overall_level,omitempty"`
 	Disability              int  `json:"disability"`
@@ -756,6 +835,7 @@ type EventAutomodSettingsUpdate struct {
 type EventAutomodTermsUpdate struct {
 	Broadcaster
 	Moderator
+	Unmodeled
 
 	Action      string   `json:"action"`
 	FromAutomod bool     `json:"from_automod"`
@@ -765,6 +845,7 @@ type EventAutomodTermsUpdate struct {
 type EventChannelChatUserMessageHold struct {
 	Broadcaster
 	User
+	Unmodeled
 
 	MessageId string      `json:"message_id"`
 	Message   ChatMessage `json:"message"`
@@ -773,17 +854,22 @@ type EventChannelChatUserMessageHold struct {
 type EventChannelChatUserMessageUpdate struct {
 	Broadcaster
 	User
+	Unmodeled
 
 	Status    string      `json:"status"`
 	MessageId string      `json:"message_id"`
 	Message   ChatMessage `json:"message"`
 }
 
-type EventChannelChatClear Broadcaster
+type EventChannelChatClear struct {
+	Broadcaster
+	Unmodeled
+}
 
 type EventChannelChatClearUserMessages struct {
 	Broadcaster
 	Target
+	Unmodeled
 }
 
 type ChatMessageUserBadge struct {
@@ -812,6 +898,7 @@ type EventChannelChatMessage struct {
 	Broadcaster
 	SourceBroadcaster
 	Chatter
+	Unmodeled
 
 	MessageId                   string                 `json:"message_id"`
 	SourceMessageId             string                 `json:"source_message_id"`
@@ -828,6 +915,7 @@ type EventChannelChatMessage struct {
 type EventChannelChatMessageDelete struct {
 	Broadcaster
 	Target
+	Unmodeled
 
 	MessageId string `json:"message_id"`
 }
@@ -888,7 +976,7 @@ type ChatNotificationPayItForward struct {
 type ChatNotificationRaid struct {
 	User
 
-	ViewerCount     string `json:"viewer_count"`
+	ViewerCount     int    `json:"viewer_count"`
 	ProfileImageUrl string `json:"profile_image_url"`
 }
 
@@ -917,6 +1005,7 @@ type EventChannelChatNotification struct {
 	Broadcaster
 	SourceBroadcaster
 	Chatter
+	Unmodeled
 
 	ChatterIsAnonymous bool                   `json:"chatter_is_anonymous"`
 	Color              string                 `json:"color"`
@@ -954,6 +1043,7 @@ type EventChannelChatNotification struct {
 
 type EventChannelChatSettingsUpdate struct {
 	Broadcaster
+	Unmodeled
 
 	EmoteMode                   bool `json:"emote_mode"`
 	FollowerMode                bool `json:"follower_mode"`
@@ -973,6 +1063,7 @@ type SuspiciousUserChatMessage struct {
 type EventChannelSuspiciousUserMessage struct {
 	Broadcaster
 	User
+	Unmodeled
 
 	LowTrustStatus       string                    `json:"low_trust_status"`
 	SharedBanChannelIds  []string                  `json:"shared_ban_channel_ids"`
@@ -985,6 +1076,7 @@ type EventChannelSuspiciousUserUpdate struct {
 	Broadcaster
 	User
 	Moderator
+	Unmodeled
 
 	LowTrustStatus string `json:"low_trust_status"`
 }
@@ -997,6 +1089,8 @@ type ConduitTransport struct {
 }
 
 type EventConduitShardDisabled struct {
+	Unmodeled
+
 	ConduitId string           `json:"conduit_id"`
 	ShardId   string           `json:"shard_id"`
 	Status    string           `json:"status"`
@@ -0,0 +1,128 @@
+package eventsubtest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+)
+
+// Clock is a twitch.Clock that only moves when it is advanced, so code waiting on
+// backoff, reconnect delays or message age can be tested without sleeping.
+type Clock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*clockTimer
+	notify chan struct{}
+}
+
+func NewClock(now time.Time) *Clock {
+	return &Clock{
+		now:    now,
+		notify: make(chan struct{}),
+	}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer returns a timer that fires once the clock is advanced past the duration.
+func (c *Clock) NewTimer(d time.Duration) twitch.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timer := &clockTimer{
+		clock: c,
+		at:    c.now.Add(d),
+		c:     make(chan time.Time, 1),
+	}
+	if d <= 0 {
+		timer.c <- c.now
+		return timer
+	}
+
+	c.timers = append(c.timers, timer)
+	close(c.notify)
+	c.notify = make(chan struct{})
+	return timer
+}
+
+// Advance moves the clock forward and fires the timers that are due, in the order they are due.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	sort.SliceStable(c.timers, func(i, j int) bool {
+		return c.timers[i].at.Before(c.timers[j].at)
+	})
+
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.at.After(c.now) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.c <- timer.at
+	}
+	c.timers = pending
+}
+
+// Timers returns how many timers are waiting to fire.
+func (c *Clock) Timers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// WaitForTimers blocks until at least n timers are waiting, so the clock is only advanced
+// once the code under test is waiting on it.
+func (c *Clock) WaitForTimers(ctx context.Context, n int) error {
+	for {
+		c.mu.Lock()
+		waiting := len(c.timers)
+		notify := c.notify
+		c.mu.Unlock()
+
+		if waiting >= n {
+			return nil
+		}
+
+		select {
+		case <-notify:
+		case <-ctx.Done():
+			return fmt.Errorf("could not wait for %d timers, %d waiting: %w", n, waiting, ctx.Err())
+		}
+	}
+}
+
+type clockTimer struct {
+	clock *Clock
+	at    time.Time
+	c     chan time.Time
+}
+
+func (t *clockTimer) C() <-chan time.Time {
+	return t.c
+}
+
+// Stop removes the timer from the clock and reports whether it was still waiting.
+func (t *clockTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	for i, timer := range t.clock.timers {
+		if timer == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package eventsubtest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v3/eventsubtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClock(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := eventsubtest.NewClock(start)
+	assert.Equal(t, start, clock.Now())
+
+	short := clock.NewTimer(time.Second)
+	long := clock.NewTimer(time.Minute)
+	stopped := clock.NewTimer(time.Second)
+	assert.Equal(t, 3, clock.Timers())
+
+	assert.True(t, stopped.Stop())
+	assert.False(t, stopped.Stop(), "a stopped timer is no longer waiting")
+
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, start.Add(30*time.Second), clock.Now())
+	select {
+	case at := <-short.C():
+		assert.Equal(t, start.Add(time.Second), at)
+	default:
+		t.Error("timer should have fired")
+	}
+	select {
+	case <-long.C():
+		t.Error("timer fired early")
+	case <-stopped.C():
+		t.Error("stopped timer fired")
+	default:
+	}
+	assert.Equal(t, 1, clock.Timers())
+
+	clock.Advance(30 * time.Second)
+	<-long.C()
+	assert.False(t, long.Stop(), "a fired timer is no longer waiting")
+
+	immediate := clock.NewTimer(0)
+	<-immediate.C()
+}
+
+func TestClockWaitForTimers(t *testing.T) {
+	t.Parallel()
+
+	clock := eventsubtest.NewClock(time.Now())
+	go clock.NewTimer(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, clock.WaitForTimers(ctx, 1))
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, clock.WaitForTimers(ctx, 2), context.DeadlineExceeded)
+}
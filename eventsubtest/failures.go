@@ -0,0 +1,147 @@
+package eventsubtest
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/coder/websocket"
+)
+
+// Close codes twitch uses when it closes a connection.
+const (
+	CloseInternalServerError       websocket.StatusCode = 4000
+	CloseClientSentInboundTraffic  websocket.StatusCode = 4001
+	CloseClientFailedPingPong      websocket.StatusCode = 4002
+	CloseConnectionUnused          websocket.StatusCode = 4003
+	CloseReconnectGraceTimeExpired websocket.StatusCode = 4004
+	CloseNetworkTimeout            websocket.StatusCode = 4005
+	CloseNetworkError              websocket.StatusCode = 4006
+	CloseInvalidReconnect          websocket.StatusCode = 4007
+)
+
+var closeReasons = map[websocket.StatusCode]string{
+	CloseInternalServerError:       "internal server error",
+	CloseClientSentInboundTraffic:  "client sent inbound traffic",
+	CloseClientFailedPingPong:      "client failed ping-pong",
+	CloseConnectionUnused:          "connection unused",
+	CloseReconnectGraceTimeExpired: "reconnect grace time expired",
+	CloseNetworkTimeout:            "network timeout",
+	CloseNetworkError:              "network error",
+	CloseInvalidReconnect:          "invalid reconnect",
+}
+
+// Step is one action of a scripted scenario.
+type Step func(session *Session) error
+
+// Run runs the steps in order and stops at the first error.
+func (s *Session) Run(steps ...Step) error {
+	for i, step := range steps {
+		err := step(s)
+		if err != nil {
+			return fmt.Errorf("step %d failed: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Drop closes the TCP connection without a close frame.
+func (s *Session) Drop() error {
+	err := s.conn.CloseNow()
+	s.markClosed()
+	return err
+}
+
+// StopKeepalives stops the keepalives sent on the server's KeepaliveInterval.
+func (s *Session) StopKeepalives() {
+	s.stopKeepaliveOnce.Do(func() { close(s.stopKeepalive) })
+}
+
+// CloseWith closes the connection with one of twitch's close codes.
+func (s *Session) CloseWith(code websocket.StatusCode) error {
+	return s.Close(code, closeReasons[code])
+}
+
+// SendMalformedJSON sends a frame that is not valid json.
+func (s *Session) SendMalformedJSON() error {
+	return s.Send([]byte(`{"metadata": {"message_id": "`))
+}
+
+// SendUnknownMessage sends a message with a message_type twitch does not send.
+func (s *Session) SendUnknownMessage(messageType string) error {
+	data, err := marshalFrame(messageType, struct{}{})
+	if err != nil {
+		return err
+	}
+	return s.Send(data)
+}
+
+// SendFailedReconnect sends a reconnect with a url nothing listens on.
+func (s *Session) SendFailedReconnect() error {
+	reconnectUrl, err := unreachableUrl()
+	if err != nil {
+		return err
+	}
+	return s.SendReconnectUrl(reconnectUrl)
+}
+
+// unreachableUrl returns a websocket url on a port that was just freed.
+func unreachableUrl() (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("could not find a free port: %w", err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+	return fmt.Sprintf("ws://%s%s", address, websocketPath), nil
+}
+
+func Wait(duration time.Duration) Step {
+	return func(session *Session) error {
+		select {
+		case <-time.After(duration):
+			return nil
+		case <-session.ctx.Done():
+			return fmt.Errorf("session %s closed while waiting", session.ID)
+		}
+	}
+}
+
+func Send(data []byte) Step {
+	return func(session *Session) error { return session.Send(data) }
+}
+
+func KeepAlive() Step {
+	return func(session *Session) error { return session.SendKeepAlive() }
+}
+
+func Drop() Step {
+	return func(session *Session) error { return session.Drop() }
+}
+
+func StopKeepalives() Step {
+	return func(session *Session) error {
+		session.StopKeepalives()
+		return nil
+	}
+}
+
+func CloseWith(code websocket.StatusCode) Step {
+	return func(session *Session) error { return session.CloseWith(code) }
+}
+
+func MalformedJSON() Step {
+	return func(session *Session) error { return session.SendMalformedJSON() }
+}
+
+func UnknownMessage(messageType string) Step {
+	return func(session *Session) error { return session.SendUnknownMessage(messageType) }
+}
+
+func Reconnect() Step {
+	return func(session *Session) error { return session.SendReconnect() }
+}
+
+func FailedReconnect() Step {
+	return func(session *Session) error { return session.SendFailedReconnect() }
+}
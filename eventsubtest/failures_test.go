@@ -0,0 +1,139 @@
+package eventsubtest_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/joeyak/go-twitch-eventsub/v3/eventsubtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// connectScenario connects a client to a server running the scenario and returns
+// the error from Connect along with the first error passed to OnError.
+func connectScenario(t *testing.T, steps ...eventsubtest.Step) (error, error) {
+	server := eventsubtest.NewServer()
+	defer server.Close()
+	server.Scenario = steps
+
+	client := twitch.NewClientWithUrl(server.URL)
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+
+	handlerErrs := make(chan error, 10)
+	client.OnError(func(err error) {
+		handlerErrs <- err
+	})
+
+	connectErr := make(chan error, 1)
+	go func() { connectErr <- client.Connect() }()
+
+	select {
+	case err := <-connectErr:
+		return err, nil
+	case err := <-handlerErrs:
+		client.Close()
+		return nil, err
+	case <-time.After(2 * time.Second):
+		t.Fatal("scenario did not end")
+		return nil, nil
+	}
+}
+
+func TestScenarios(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name       string
+		Steps      []eventsubtest.Step
+		ConnectErr bool
+		HandlerErr string
+	}{
+		{"Drop", []eventsubtest.Step{eventsubtest.Drop()}, true, ""},
+		{"MalformedJSON", []eventsubtest.Step{eventsubtest.MalformedJSON()}, false, "could not unmarshal basemessage"},
+		{"UnknownMessage", []eventsubtest.Step{eventsubtest.UnknownMessage("session_party")}, false, "unknown message type session_party"},
+		{"FailedReconnect", []eventsubtest.Step{eventsubtest.FailedReconnect()}, false, "could not handle reconnect"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			connectErr, handlerErr := connectScenario(t, tc.Steps...)
+			if tc.ConnectErr {
+				assert.Error(t, connectErr)
+			}
+			if tc.HandlerErr != "" {
+				assert.ErrorContains(t, handlerErr, tc.HandlerErr)
+			}
+		})
+	}
+}
+
+func TestCloseCodes(t *testing.T) {
+	t.Parallel()
+
+	for code := eventsubtest.CloseInternalServerError; code <= eventsubtest.CloseInvalidReconnect; code++ {
+		code := code
+		t.Run(code.String(), func(t *testing.T) {
+			t.Parallel()
+
+			connectErr, _ := connectScenario(t, eventsubtest.CloseWith(code))
+			assert.Equal(t, code, websocket.CloseStatus(connectErr))
+		})
+	}
+}
+
+func TestWelcomeDelay(t *testing.T) {
+	t.Parallel()
+
+	server := eventsubtest.NewServer()
+	defer server.Close()
+	server.WelcomeDelay = 50 * time.Millisecond
+
+	client := twitch.NewClientWithUrl(server.URL)
+	welcomed := make(chan time.Time, 1)
+	client.OnWelcome(func(message twitch.WelcomeMessage) {
+		welcomed <- time.Now()
+	})
+
+	start := time.Now()
+	go client.Connect()
+	defer client.Close()
+
+	select {
+	case at := <-welcomed:
+		assert.GreaterOrEqual(t, at.Sub(start), server.WelcomeDelay)
+	case <-time.After(time.Second):
+		t.Fatal("welcome was not received")
+	}
+}
+
+func TestStopKeepalives(t *testing.T) {
+	t.Parallel()
+
+	server := eventsubtest.NewServer()
+	defer server.Close()
+	server.KeepaliveInterval = 5 * time.Millisecond
+
+	var keepalives int32
+	client := twitch.NewClientWithUrl(server.URL)
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+	client.OnKeepAlive(func(message twitch.KeepAliveMessage) {
+		atomic.AddInt32(&keepalives, 1)
+	})
+
+	go client.Connect()
+	defer client.Close()
+
+	session := nextSession(t, server)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&keepalives) >= 2 }, time.Second, time.Millisecond)
+
+	session.StopKeepalives()
+	time.Sleep(20 * time.Millisecond)
+	stopped := atomic.LoadInt32(&keepalives)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, stopped, atomic.LoadInt32(&keepalives))
+}
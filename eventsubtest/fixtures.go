@@ -0,0 +1,161 @@
+package eventsubtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	_ "embed"
+
+	"github.com/google/uuid"
+	"github.com/joeyak/go-twitch-eventsub/v3"
+)
+
+// samples holds a realistic event for every subscription type, keyed by the type
+// and an optional variant such as channel.cheer-anon. samples_gen.json holds the
+// samples of the subscription spec and is written by go generate.
+var (
+	//go:embed samples.json
+	samplesJSON []byte
+	//go:embed samples_gen.json
+	generatedSamplesJSON []byte
+)
+
+var samples = func() map[string]json.RawMessage {
+	var events map[string]json.RawMessage
+	err := json.Unmarshal(samplesJSON, &events)
+	if err != nil {
+		panic(fmt.Sprintf("could not parse samples.json: %v", err))
+	}
+
+	var generated map[string]json.RawMessage
+	err = json.Unmarshal(generatedSamplesJSON, &generated)
+	if err != nil {
+		panic(fmt.Sprintf("could not parse samples_gen.json: %v", err))
+	}
+	for key, event := range generated {
+		events[key] = event
+	}
+	return events
+}()
+
+// SampleEvent returns the json of a realistic event. Variants pick other samples of
+// the same type, e.g. SampleEvent(twitch.SubChannelCheer, "anon").
+func SampleEvent(event twitch.EventSubscription, variants ...string) (json.RawMessage, error) {
+	key := strings.Join(append([]string{string(event)}, variants...), "-")
+	data, ok := samples[key]
+	if !ok {
+		return nil, fmt.Errorf("could not find sample %s", key)
+	}
+	return append(json.RawMessage(nil), data...), nil
+}
+
+// Sample decodes a realistic event into its type.
+func Sample[T any](event twitch.EventSubscription, variants ...string) (T, error) {
+	var sample T
+
+	data, err := SampleEvent(event, variants...)
+	if err != nil {
+		return sample, err
+	}
+
+	err = json.Unmarshal(data, &sample)
+	if err != nil {
+		return sample, fmt.Errorf("could not unmarshal %s sample into %T: %w", event, sample, err)
+	}
+	return sample, nil
+}
+
+// SampleVariants lists the variants of the samples of a type, the default sample is the empty string.
+func SampleVariants(event twitch.EventSubscription) []string {
+	var variants []string
+	for key := range samples {
+		if key == string(event) {
+			variants = append(variants, "")
+		} else if strings.HasPrefix(key, string(event)+"-") {
+			variants = append(variants, strings.TrimPrefix(key, string(event)+"-"))
+		}
+	}
+	sort.Strings(variants)
+	return variants
+}
+
+// Subscription returns an enabled websocket subscription to the event with the version twitch
+// currently uses for it. Unknown events get version 1.
+func Subscription(event twitch.EventSubscription, condition map[string]string) twitch.PayloadSubscription {
+	version := "1"
+	if details, ok := twitch.SubscriptionInfo(event); ok {
+		version = details.Version
+	}
+	if condition == nil {
+		condition = map[string]string{}
+	}
+
+	return twitch.PayloadSubscription{
+		SubscriptionRequest: twitch.SubscriptionRequest{
+			Type:      event,
+			Version:   version,
+			Condition: condition,
+			Transport: twitch.SubscriptionTransport{
+				Method:    "websocket",
+				SessionID: newSessionID(),
+			},
+		},
+		ID:       uuid.NewString(),
+		Status:   "enabled",
+		Cost:     0,
+		CreateAt: time.Now().UTC(),
+	}
+}
+
+// Notification returns a notification frame with the event. The subscription condition
+// is filled from the fields of the event that match the condition keys of its type.
+func Notification(event twitch.EventSubscription, payload any) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal %s event: %w", event, err)
+	}
+	return notificationFrame(Subscription(event, conditionFromEvent(event, data)), json.RawMessage(data))
+}
+
+// SampleNotification returns a notification frame with a realistic event.
+func SampleNotification(event twitch.EventSubscription, variants ...string) ([]byte, error) {
+	data, err := SampleEvent(event, variants...)
+	if err != nil {
+		return nil, err
+	}
+	return Notification(event, data)
+}
+
+// Revocation returns a revocation frame for the subscription.
+func Revocation(subscription twitch.PayloadSubscription, reason twitch.RevocationReason) ([]byte, error) {
+	return revocationFrame(subscription, reason)
+}
+
+func KeepAliveMessage() ([]byte, error) {
+	return keepAliveFrame()
+}
+
+func conditionFromEvent(event twitch.EventSubscription, data []byte) map[string]string {
+	condition := map[string]string{}
+
+	details, ok := twitch.SubscriptionInfo(event)
+	if !ok {
+		return condition
+	}
+
+	var fields map[string]any
+	if json.Unmarshal(data, &fields) != nil {
+		return condition
+	}
+
+	keys := append(append(details.RequiredConditions, details.OptionalConditions...), details.OneOfConditions...)
+	for _, key := range keys {
+		if value, ok := fields[key].(string); ok && value != "" {
+			condition[key] = value
+		}
+	}
+	return condition
+}
@@ -0,0 +1,95 @@
+package eventsubtest_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/joeyak/go-twitch-eventsub/v3/eventsubtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampleNotifications(t *testing.T) {
+	t.Parallel()
+
+	for _, event := range twitch.SubscriptionTypes() {
+		event := event
+		t.Run(string(event), func(t *testing.T) {
+			t.Parallel()
+
+			variants := eventsubtest.SampleVariants(event)
+			assert.NotEmpty(t, variants, "every subscription type should have a sample")
+
+			for _, variant := range variants {
+				var data []byte
+				var err error
+				if variant == "" {
+					data, err = eventsubtest.SampleNotification(event)
+				} else {
+					data, err = eventsubtest.SampleNotification(event, variant)
+				}
+				if !assert.NoError(t, err) {
+					continue
+				}
+
+				var message twitch.NotificationMessage
+				assert.NoError(t, json.Unmarshal(data, &message))
+				assert.Equal(t, "notification", message.Metadata.MessageType)
+				assert.Equal(t, event, message.Payload.Subscription.Type)
+
+				details, _ := twitch.SubscriptionInfo(event)
+				assert.Equal(t, details.Version, message.Payload.Subscription.Version)
+			}
+		})
+	}
+}
+
+func TestSample(t *testing.T) {
+	t.Parallel()
+
+	cheer, err := eventsubtest.Sample[twitch.EventChannelCheer](twitch.SubChannelCheer, "anon")
+	assert.NoError(t, err)
+	assert.True(t, cheer.IsAnonymous)
+
+	_, err = eventsubtest.Sample[twitch.EventChannelCheer](twitch.SubChannelCheer, "missing")
+	assert.Error(t, err)
+}
+
+func TestNotification(t *testing.T) {
+	t.Parallel()
+
+	server := eventsubtest.NewServer()
+	defer server.Close()
+
+	client := twitch.NewClientWithUrl(server.URL)
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+	cheers := make(chan twitch.EventChannelCheer, 1)
+	client.OnEventChannelCheer(func(event twitch.EventChannelCheer) {
+		cheers <- event
+	})
+	notifications := make(chan twitch.NotificationMessage, 1)
+	client.OnNotification(func(message twitch.NotificationMessage) {
+		notifications <- message
+	})
+
+	go client.Connect()
+	defer client.Close()
+
+	data, err := eventsubtest.Notification(twitch.SubChannelCheer, twitch.EventChannelCheer{
+		Broadcaster: twitch.Broadcaster{BroadcasterUserId: "1337"},
+		Bits:        100,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, nextSession(t, server).Send(data))
+
+	select {
+	case cheer := <-cheers:
+		assert.Equal(t, 100, cheer.Bits)
+	case <-time.After(time.Second):
+		t.Fatal("cheer was not received")
+	}
+
+	message := <-notifications
+	assert.Equal(t, map[string]string{"broadcaster_user_id": "1337"}, message.Payload.Subscription.Condition)
+}
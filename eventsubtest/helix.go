@@ -0,0 +1,213 @@
+package eventsubtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+)
+
+// HelixRequest is a request received by the fake Helix endpoint.
+type HelixRequest struct {
+	Method      string
+	Query       url.Values
+	ClientID    string
+	AccessToken string
+	Body        []byte
+}
+
+type helix struct {
+	server *Server
+
+	mu            sync.Mutex
+	maxTotalCost  int
+	subscriptions []twitch.PayloadSubscription
+	requests      []HelixRequest
+	created       int
+}
+
+func newHelix(server *Server) *helix {
+	return &helix{
+		server:       server,
+		maxTotalCost: defaultMaxTotalCost,
+	}
+}
+
+func (h *helix) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	accessToken := r.Header.Get("Authorization")
+	if len(accessToken) > len("Bearer ") {
+		accessToken = accessToken[len("Bearer "):]
+	}
+	h.requests = append(h.requests, HelixRequest{
+		Method:      r.Method,
+		Query:       r.URL.Query(),
+		ClientID:    r.Header.Get("Client-Id"),
+		AccessToken: accessToken,
+		Body:        body,
+	})
+
+	switch r.Method {
+	case http.MethodGet:
+		h.get(w, r)
+	case http.MethodPost:
+		h.post(w, body)
+	case http.MethodDelete:
+		h.delete(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (h *helix) get(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	data := []twitch.PayloadSubscription{}
+	for _, subscription := range h.subscriptions {
+		if value := query.Get("type"); value != "" && string(subscription.Type) != value {
+			continue
+		}
+		if value := query.Get("status"); value != "" && subscription.Status != value {
+			continue
+		}
+		if value := query.Get("subscription_id"); value != "" && subscription.ID != value {
+			continue
+		}
+		data = append(data, subscription)
+	}
+
+	writeJSON(w, http.StatusOK, twitch.GetSubscriptionsResponse{
+		SubscribeResponse: twitch.SubscribeResponse{
+			Data:         data,
+			Total:        len(data),
+			MaxTotalCost: h.maxTotalCost,
+		},
+	})
+}
+
+func (h *helix) post(w http.ResponseWriter, body []byte) {
+	var request twitch.SubscriptionRequest
+	err := json.Unmarshal(body, &request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "malformed request body")
+		return
+	}
+
+	if request.Transport.Method == "websocket" {
+		session, ok := h.server.Session(request.Transport.SessionID)
+		if !ok || session.Closed() {
+			writeError(w, http.StatusBadRequest, "websocket transport session does not exist or has already disconnected")
+			return
+		}
+	}
+
+	totalCost := 0
+	for _, subscription := range h.subscriptions {
+		if subscription.Transport != request.Transport {
+			continue
+		}
+		if subscription.Type == request.Type && fmt.Sprint(subscription.Condition) == fmt.Sprint(request.Condition) {
+			writeError(w, http.StatusConflict, "subscription already exists")
+			return
+		}
+		totalCost += subscription.Cost
+	}
+
+	if totalCost+1 > h.maxTotalCost {
+		writeError(w, http.StatusTooManyRequests, "max total cost exceeded")
+		return
+	}
+
+	h.created++
+	subscription := twitch.PayloadSubscription{
+		SubscriptionRequest: request,
+		ID:                  fmt.Sprintf("subscription-%d", h.created),
+		Status:              "enabled",
+		Cost:                1,
+		CreateAt:            time.Now().UTC(),
+	}
+	h.subscriptions = append(h.subscriptions, subscription)
+
+	writeJSON(w, http.StatusAccepted, twitch.SubscribeResponse{
+		Data:         []twitch.PayloadSubscription{subscription},
+		Total:        len(h.subscriptions),
+		TotalCost:    totalCost + subscription.Cost,
+		MaxTotalCost: h.maxTotalCost,
+	})
+}
+
+func (h *helix) delete(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	for i, subscription := range h.subscriptions {
+		if subscription.ID == id {
+			h.subscriptions = append(h.subscriptions[:i], h.subscriptions[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "subscription not found")
+}
+
+func (h *helix) list() []twitch.PayloadSubscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]twitch.PayloadSubscription(nil), h.subscriptions...)
+}
+
+func (h *helix) recorded() []HelixRequest {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]HelixRequest(nil), h.requests...)
+}
+
+func (h *helix) setMaxTotalCost(maxTotalCost int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.maxTotalCost = maxTotalCost
+}
+
+func (h *helix) remove(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, subscription := range h.subscriptions {
+		if subscription.ID == id {
+			h.subscriptions = append(h.subscriptions[:i], h.subscriptions[i+1:]...)
+			return
+		}
+	}
+}
+
+func (h *helix) migrate(from, to string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, subscription := range h.subscriptions {
+		if subscription.Transport.SessionID == from {
+			h.subscriptions[i].Transport.SessionID = to
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{
+		"error":   http.StatusText(status),
+		"status":  status,
+		"message": message,
+	})
+}
@@ -0,0 +1,69 @@
+package eventsubtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joeyak/go-twitch-eventsub/v3"
+)
+
+type frame struct {
+	Metadata twitch.MessageMetadata `json:"metadata"`
+	Payload  any                    `json:"payload"`
+}
+
+type sessionPayload struct {
+	Session twitch.PayloadSession `json:"session"`
+}
+
+type subscriptionPayload struct {
+	Subscription twitch.PayloadSubscription `json:"subscription"`
+	Event        any                        `json:"event,omitempty"`
+}
+
+func newMetadata(messageType string) twitch.MessageMetadata {
+	return twitch.MessageMetadata{
+		MessageID:        uuid.NewString(),
+		MessageType:      messageType,
+		MessageTimestamp: time.Now().UTC(),
+	}
+}
+
+func newSessionID() string {
+	return strings.ReplaceAll(uuid.NewString(), "-", "")
+}
+
+func marshalFrame(messageType string, payload any) ([]byte, error) {
+	data, err := json.Marshal(frame{
+		Metadata: newMetadata(messageType),
+		Payload:  payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal %s message: %w", messageType, err)
+	}
+	return data, nil
+}
+
+func welcomeFrame(session twitch.PayloadSession) ([]byte, error) {
+	return marshalFrame("session_welcome", sessionPayload{Session: session})
+}
+
+func keepAliveFrame() ([]byte, error) {
+	return marshalFrame("session_keepalive", struct{}{})
+}
+
+func reconnectFrame(session twitch.PayloadSession) ([]byte, error) {
+	return marshalFrame("session_reconnect", sessionPayload{Session: session})
+}
+
+func notificationFrame(subscription twitch.PayloadSubscription, event any) ([]byte, error) {
+	return marshalFrame("notification", subscriptionPayload{Subscription: subscription, Event: event})
+}
+
+func revocationFrame(subscription twitch.PayloadSubscription, reason twitch.RevocationReason) ([]byte, error) {
+	subscription.Status = string(reason)
+	return marshalFrame("revocation", subscriptionPayload{Subscription: subscription})
+}
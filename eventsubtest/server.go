@@ -0,0 +1,217 @@
+// Package eventsubtest provides a local EventSub websocket server and a fake Helix
+// subscriptions endpoint so code using the twitch package can be tested offline.
+package eventsubtest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/joeyak/go-twitch-eventsub/v3"
+)
+
+const (
+	defaultKeepaliveTimeoutSeconds = 10
+	defaultMaxTotalCost            = 10
+
+	websocketPath = "/ws"
+	helixPath     = "/eventsub/subscriptions"
+)
+
+// Server is a local EventSub websocket server. Every connection gets a session_welcome
+// and is then driven from the test through its Session. Subscriptions created through
+// the fake Helix endpoint at HelixURL belong to the session they name.
+type Server struct {
+	// URL is the websocket address to give to twitch.NewClientWithUrl.
+	URL string
+	// HelixURL is the subscriptions endpoint to give to twitch.NewSubscriptionClientWithUrl.
+	HelixURL string
+
+	// KeepaliveTimeoutSeconds is sent in the welcome of new sessions.
+	KeepaliveTimeoutSeconds int
+	// KeepaliveInterval sends a session_keepalive to every session on this interval when set.
+	KeepaliveInterval time.Duration
+	// WelcomeDelay is how long new connections wait for their session_welcome.
+	WelcomeDelay time.Duration
+	// Scenario is run on every new session after its welcome.
+	Scenario []Step
+
+	server *httptest.Server
+	helix  *helix
+
+	mu       sync.Mutex
+	sessions []*Session
+	next     int
+	notify   chan struct{}
+}
+
+func NewServer() *Server {
+	s := &Server{
+		KeepaliveTimeoutSeconds: defaultKeepaliveTimeoutSeconds,
+		notify:                  make(chan struct{}),
+	}
+	s.helix = newHelix(s)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(websocketPath, s.handleWebsocket)
+	mux.Handle(helixPath, s.helix)
+
+	s.server = httptest.NewServer(mux)
+	s.URL = strings.Replace(s.server.URL, "http://", "ws://", 1) + websocketPath
+	s.HelixURL = s.server.URL + helixPath
+	return s
+}
+
+// SubscriptionClient returns a client that sends its requests to the fake Helix endpoint.
+func (s *Server) SubscriptionClient() *twitch.SubscriptionClient {
+	client := twitch.NewSubscriptionClientWithUrl(s.HelixURL)
+	client.MinBackoff = time.Millisecond
+	client.MaxBackoff = 10 * time.Millisecond
+	return client
+}
+
+func (s *Server) Close() {
+	s.mu.Lock()
+	sessions := append([]*Session(nil), s.sessions...)
+	s.mu.Unlock()
+
+	for _, session := range sessions {
+		session.Close(websocket.StatusGoingAway, "server closed")
+	}
+	s.server.Close()
+}
+
+// NextSession waits for the next connection that has not been returned yet, in the order they connected.
+func (s *Server) NextSession(ctx context.Context) (*Session, error) {
+	for {
+		s.mu.Lock()
+		if s.next < len(s.sessions) {
+			session := s.sessions[s.next]
+			s.next++
+			s.mu.Unlock()
+			return session, nil
+		}
+		notify := s.notify
+		s.mu.Unlock()
+
+		select {
+		case <-notify:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("could not get next session: %w", ctx.Err())
+		}
+	}
+}
+
+// Sessions returns the sessions that are still connected.
+func (s *Server) Sessions() []*Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var sessions []*Session
+	for _, session := range s.sessions {
+		if !session.Closed() {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions
+}
+
+// Session returns the session with the id.
+func (s *Server) Session(id string) (*Session, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, session := range s.sessions {
+		if session.ID == id {
+			return session, true
+		}
+	}
+	return nil, false
+}
+
+// Subscriptions returns every subscription created through the fake Helix endpoint.
+func (s *Server) Subscriptions() []twitch.PayloadSubscription {
+	return s.helix.list()
+}
+
+// Requests returns every request the fake Helix endpoint received.
+func (s *Server) Requests() []HelixRequest {
+	return s.helix.recorded()
+}
+
+// SetMaxTotalCost changes the max total cost reported by the fake Helix endpoint.
+func (s *Server) SetMaxTotalCost(maxTotalCost int) {
+	s.helix.setMaxTotalCost(maxTotalCost)
+}
+
+func (s *Server) handleWebsocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	session := &Session{
+		ID:     newSessionID(),
+		server: s,
+		conn:   conn,
+		ctx:    ctx,
+		cancel: cancel,
+
+		stopKeepalive: make(chan struct{}),
+	}
+	session.payload = twitch.PayloadSession{
+		ID:                      session.ID,
+		Status:                  "connected",
+		ConnectedAt:             time.Now().UTC(),
+		KeepaliveTimeoutSeconds: s.KeepaliveTimeoutSeconds,
+	}
+
+	// A reconnect moves the subscriptions of the old session to the new one
+	if from := r.URL.Query().Get("reconnect"); from != "" {
+		s.helix.migrate(from, session.ID)
+	}
+
+	if s.WelcomeDelay > 0 {
+		select {
+		case <-time.After(s.WelcomeDelay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	err = session.sendWelcome()
+	if err != nil {
+		conn.Close(websocket.StatusInternalError, "could not send welcome")
+		return
+	}
+
+	s.mu.Lock()
+	s.sessions = append(s.sessions, session)
+	close(s.notify)
+	s.notify = make(chan struct{})
+	s.mu.Unlock()
+
+	if s.KeepaliveInterval > 0 {
+		go session.keepAlive(s.KeepaliveInterval)
+	}
+	if len(s.Scenario) > 0 {
+		go session.Run(s.Scenario...)
+	}
+
+	// Read so the close handshake is handled
+	for {
+		_, _, err := conn.Read(ctx)
+		if err != nil {
+			break
+		}
+	}
+	session.markClosed()
+}
@@ -0,0 +1,130 @@
+package eventsubtest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/joeyak/go-twitch-eventsub/v3/eventsubtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newManagedClient(t *testing.T, server *eventsubtest.Server) (*twitch.Client, *twitch.SubscriptionManager) {
+	client := twitch.NewClientWithUrl(server.URL)
+	client.OnError(func(err error) {})
+
+	manager := twitch.NewSubscriptionManager(client)
+	manager.Subscriber = server.SubscriptionClient()
+	assert.NoError(t, manager.Add(context.Background(), twitch.SubscribeRequest{
+		ClientID:       "client",
+		AccessToken:    "token",
+		Event:          twitch.SubStreamOnline,
+		TypedCondition: twitch.BroadcasterCondition{BroadcasterUserID: "1337"},
+	}))
+
+	go client.Connect()
+	t.Cleanup(func() { client.Close() })
+	return client, manager
+}
+
+func nextSession(t *testing.T, server *eventsubtest.Server) *eventsubtest.Session {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	session, err := server.NextSession(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return session
+}
+
+func waitForSubscriptions(t *testing.T, session *eventsubtest.Session, count int) {
+	assert.Eventually(t, func() bool { return len(session.Subscriptions()) == count }, time.Second, 10*time.Millisecond)
+}
+
+func TestServerNotification(t *testing.T) {
+	t.Parallel()
+
+	server := eventsubtest.NewServer()
+	defer server.Close()
+
+	client, _ := newManagedClient(t, server)
+
+	events := make(chan twitch.EventStreamOnline, 1)
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		events <- event
+	})
+
+	session := nextSession(t, server)
+	waitForSubscriptions(t, session, 1)
+
+	assert.NoError(t, session.Notify(twitch.SubStreamOnline, twitch.EventStreamOnline{
+		Broadcaster: twitch.Broadcaster{BroadcasterUserId: "1337"},
+		Type:        "live",
+	}))
+
+	select {
+	case event := <-events:
+		assert.Equal(t, "1337", event.BroadcasterUserId)
+	case <-time.After(time.Second):
+		t.Fatal("notification was not received")
+	}
+
+	requests := server.Requests()
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, http.MethodPost, requests[0].Method)
+		assert.Equal(t, "client", requests[0].ClientID)
+		assert.Equal(t, "token", requests[0].AccessToken)
+	}
+}
+
+func TestServerReconnect(t *testing.T) {
+	t.Parallel()
+
+	server := eventsubtest.NewServer()
+	defer server.Close()
+
+	_, manager := newManagedClient(t, server)
+
+	session := nextSession(t, server)
+	waitForSubscriptions(t, session, 1)
+
+	assert.NoError(t, session.SendReconnect())
+
+	reconnected := nextSession(t, server)
+	assert.Eventually(t, func() bool { return manager.SessionID() == reconnected.ID }, time.Second, 10*time.Millisecond)
+	assert.Len(t, reconnected.Subscriptions(), 1, "subscriptions move to the new session")
+	assert.Len(t, server.Requests(), 1, "subscriptions are not made again after a reconnect")
+}
+
+func TestServerRevocation(t *testing.T) {
+	t.Parallel()
+
+	server := eventsubtest.NewServer()
+	defer server.Close()
+
+	client, manager := newManagedClient(t, server)
+	manager.RevocationPolicy = func(twitch.SubscriptionStatus, twitch.RevocationReason) twitch.RevocationAction {
+		return twitch.RevocationDrop
+	}
+
+	revocations := make(chan twitch.RevokeMessage, 1)
+	client.OnRevoke(func(message twitch.RevokeMessage) {
+		revocations <- message
+	})
+
+	session := nextSession(t, server)
+	waitForSubscriptions(t, session, 1)
+
+	assert.NoError(t, session.SendRevocation(session.Subscriptions()[0], twitch.RevocationUserRemoved))
+
+	select {
+	case message := <-revocations:
+		assert.Equal(t, twitch.RevocationUserRemoved, message.Reason())
+	case <-time.After(time.Second):
+		t.Fatal("revocation was not received")
+	}
+	assert.Empty(t, server.Subscriptions())
+}
@@ -0,0 +1,172 @@
+package eventsubtest
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/joeyak/go-twitch-eventsub/v3"
+)
+
+// Session is a connection to the Server.
+type Session struct {
+	ID string
+
+	server  *Server
+	conn    *websocket.Conn
+	ctx     context.Context
+	cancel  context.CancelFunc
+	payload twitch.PayloadSession
+
+	stopKeepalive     chan struct{}
+	stopKeepaliveOnce sync.Once
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// Send writes a raw frame to the connection.
+func (s *Session) Send(data []byte) error {
+	err := s.conn.Write(s.ctx, websocket.MessageText, data)
+	if err != nil {
+		return fmt.Errorf("could not send to session %s: %w", s.ID, err)
+	}
+	return nil
+}
+
+func (s *Session) SendKeepAlive() error {
+	data, err := keepAliveFrame()
+	if err != nil {
+		return err
+	}
+	return s.Send(data)
+}
+
+// SendNotification sends the event as a notification of the subscription.
+func (s *Session) SendNotification(subscription twitch.PayloadSubscription, event any) error {
+	data, err := notificationFrame(subscription, event)
+	if err != nil {
+		return err
+	}
+	return s.Send(data)
+}
+
+// Notify sends the event to every subscription of the session with the type.
+func (s *Session) Notify(event twitch.EventSubscription, payload any) error {
+	subscriptions := s.Subscriptions()
+
+	sent := false
+	for _, subscription := range subscriptions {
+		if subscription.Type != event {
+			continue
+		}
+
+		err := s.SendNotification(subscription, payload)
+		if err != nil {
+			return err
+		}
+		sent = true
+	}
+
+	if !sent {
+		return fmt.Errorf("session %s has no %s subscription", s.ID, event)
+	}
+	return nil
+}
+
+// SendRevocation revokes the subscription and tells the session.
+func (s *Session) SendRevocation(subscription twitch.PayloadSubscription, reason twitch.RevocationReason) error {
+	s.server.helix.remove(subscription.ID)
+
+	data, err := revocationFrame(subscription, reason)
+	if err != nil {
+		return err
+	}
+	return s.Send(data)
+}
+
+// SendReconnect asks the client to reconnect to the server. The subscriptions of the session
+// are moved to the session of the new connection.
+func (s *Session) SendReconnect() error {
+	return s.SendReconnectUrl(fmt.Sprintf("%s?%s", s.server.URL, url.Values{"reconnect": {s.ID}}.Encode()))
+}
+
+// SendReconnectUrl asks the client to reconnect to a url.
+func (s *Session) SendReconnectUrl(reconnectUrl string) error {
+	session := s.payload
+	session.Status = "reconnecting"
+	session.ReconnectUrl = reconnectUrl
+
+	data, err := reconnectFrame(session)
+	if err != nil {
+		return err
+	}
+	return s.Send(data)
+}
+
+// Subscriptions returns the subscriptions made for the session.
+func (s *Session) Subscriptions() []twitch.PayloadSubscription {
+	var subscriptions []twitch.PayloadSubscription
+	for _, subscription := range s.server.helix.list() {
+		if subscription.Transport.SessionID == s.ID {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	return subscriptions
+}
+
+// Close closes the connection with a close frame.
+func (s *Session) Close(code websocket.StatusCode, reason string) error {
+	if s.Closed() {
+		return nil
+	}
+
+	err := s.conn.Close(code, reason)
+	s.markClosed()
+	return err
+}
+
+func (s *Session) Closed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+func (s *Session) markClosed() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		s.cancel()
+	}
+}
+
+func (s *Session) sendWelcome() error {
+	data, err := welcomeFrame(s.payload)
+	if err != nil {
+		return err
+	}
+	return s.Send(data)
+}
+
+func (s *Session) keepAlive(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-s.stopKeepalive:
+			return
+		case <-ticker.C:
+			if s.SendKeepAlive() != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package twitch
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// NewEvent returns a pointer to a new event of the type the subscription delivers.
+func NewEvent(event EventSubscription) (any, bool) {
+	metadata, ok := subMetadata[event]
+	if !ok || metadata.EventGen == nil {
+		return nil, false
+	}
+	return metadata.EventGen(), true
+}
+
+// UnknownFields lists the json paths of the event that have no field in its type.
+func UnknownFields(event EventSubscription, data []byte) []string {
+	newEvent, ok := NewEvent(event)
+	if !ok {
+		return nil
+	}
+
+	unknown := map[string]json.RawMessage{}
+	unknownFields(data, reflect.TypeOf(newEvent), "", unknown)
+
+	var fields []string
+	for field := range unknown {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// HasConditionKind reports if the kind of the condition key is known.
+func HasConditionKind(key string) bool {
+	_, ok := conditionKinds[key]
+	return ok
+}
@@ -0,0 +1,163 @@
+package twitch_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/joeyak/go-twitch-eventsub/v3/eventsubtest"
+	"github.com/stretchr/testify/assert"
+)
+
+type frameSource [][]byte
+
+func (s *frameSource) Next(ctx context.Context) ([]byte, error) {
+	if len(*s) == 0 {
+		return nil, io.EOF
+	}
+	data := (*s)[0]
+	*s = (*s)[1:]
+	return data, nil
+}
+
+// handleFrames runs the frames through a client and returns the errors it reported.
+func handleFrames(t *testing.T, frames ...[]byte) []error {
+	source := frameSource(frames)
+	client := twitch.NewClient()
+	client.SetFrameSource(&source)
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+
+	var errs []error
+	client.OnError(func(err error) {
+		errs = append(errs, err)
+	})
+
+	assert.NoError(t, client.Connect())
+	return errs
+}
+
+func addSampleSeeds(f *testing.F) {
+	for _, event := range twitch.SubscriptionTypes() {
+		for _, variant := range eventsubtest.SampleVariants(event) {
+			var variants []string
+			if variant != "" {
+				variants = append(variants, variant)
+			}
+
+			data, err := eventsubtest.SampleEvent(event, variants...)
+			if err != nil {
+				f.Fatal(err)
+			}
+			f.Add(string(event), []byte(data))
+		}
+	}
+}
+
+func FuzzHandleMessage(f *testing.F) {
+	addSampleSeeds(f)
+	f.Add("session_welcome", newWelcomeFrameData(f, "session"))
+	f.Add("session_keepalive", []byte(`{"metadata":{"message_type":"session_keepalive"},"payload":{}}`))
+	f.Add("", []byte(`{"metadata":{"message_type":"notification"},"payload":{"subscription":{"type":"channel.follow"}}}`))
+	f.Add("", []byte(`{"metadata":{"message_type":"notification"},"payload":{"event":null}}`))
+	f.Add("", []byte(`{"metadata":{"message_type":`))
+
+	f.Fuzz(func(t *testing.T, event string, data []byte) {
+		if event != "" {
+			frame, err := eventsubtest.Notification(twitch.EventSubscription(event), json.RawMessage(data))
+			if err == nil {
+				data = frame
+			}
+		}
+
+		// Any frame must be handled without panicking, and one that is not json is reported once as malformed
+		errs := handleFrames(t, data)
+		if json.Valid(data) {
+			return
+		}
+
+		var decodeErr *twitch.DecodeError
+		if assert.Len(t, errs, 1) && assert.ErrorAs(t, errs[0], &decodeErr) {
+			assert.Empty(t, decodeErr.Value, "malformed json has no value: %v", decodeErr)
+		}
+	})
+}
+
+func FuzzEvents(f *testing.F) {
+	addSampleSeeds(f)
+
+	f.Fuzz(func(t *testing.T, event string, data []byte) {
+		details, ok := twitch.SubscriptionInfo(twitch.EventSubscription(event))
+		if !ok || !json.Valid(data) {
+			return
+		}
+
+		frame, err := eventsubtest.Notification(details.Type, json.RawMessage(data))
+		if err != nil {
+			return
+		}
+
+		for _, err := range handleFrames(t, frame) {
+			var decodeErr *twitch.DecodeError
+			assert.True(t, errors.As(err, &decodeErr), "errors of valid json should name what did not match: %v", err)
+		}
+	})
+}
+
+func TestDecodeErrorField(t *testing.T) {
+	t.Parallel()
+
+	data, err := eventsubtest.SampleEvent(twitch.SubChannelChatNotification, "raid")
+	assert.NoError(t, err)
+
+	var event map[string]any
+	assert.NoError(t, json.Unmarshal(data, &event))
+	event["raid"].(map[string]any)["viewer_count"] = "many"
+
+	frame, err := eventsubtest.Notification(twitch.SubChannelChatNotification, event)
+	assert.NoError(t, err)
+
+	errs := handleFrames(t, frame)
+	if assert.Len(t, errs, 1) {
+		var decodeErr *twitch.DecodeError
+		if assert.ErrorAs(t, errs[0], &decodeErr) {
+			assert.Equal(t, "raid.viewer_count", decodeErr.Field)
+			assert.Equal(t, "string", decodeErr.Value)
+			assert.Equal(t, "int", decodeErr.Type)
+		}
+	}
+}
+
+func TestNotificationWithoutEvent(t *testing.T) {
+	t.Parallel()
+
+	errs := handleFrames(t, []byte(`{"metadata":{"message_type":"notification"},"payload":{"subscription":{"type":"channel.follow"}}}`))
+	if assert.Len(t, errs, 1) {
+		assert.ErrorContains(t, errs[0], "has no event")
+	}
+}
+
+func TestDecodeErrorFieldFromUnmarshaler(t *testing.T) {
+	t.Parallel()
+
+	data, err := eventsubtest.SampleEvent(twitch.SubChannelFollow)
+	assert.NoError(t, err)
+
+	var event map[string]any
+	assert.NoError(t, json.Unmarshal(data, &event))
+	event["followed_at"] = "yesterday"
+
+	frame, err := eventsubtest.Notification(twitch.SubChannelFollow, event)
+	assert.NoError(t, err)
+
+	errs := handleFrames(t, frame)
+	if assert.Len(t, errs, 1) {
+		var decodeErr *twitch.DecodeError
+		if assert.ErrorAs(t, errs[0], &decodeErr) {
+			assert.Equal(t, "followed_at", decodeErr.Field)
+			assert.Equal(t, "time.Time", decodeErr.Type)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package twitch
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// handlers holds the callbacks registered on a Client.
+// It is embedded so the same set of callbacks can be shared by several clients.
+type handlers struct {
+	// Responses
+	onError        func(err error)
+	onWelcome      func(message WelcomeMessage)
+	onKeepAlive    func(message KeepAliveMessage)
+	onNotification func(message NotificationMessage)
+	onReconnect    func(message ReconnectMessage)
+	onRevoke       func(message RevokeMessage)
+
+	// Decoding
+	decodingMode   DecodingMode
+	onUnknownField func(event EventSubscription, field string, value json.RawMessage)
+	unknownMu      sync.Mutex
+	unknownSeen    map[string]bool
+
+	// Events, the typed callbacks are generated in handlers_gen.go
+	onRawEvent func(event string, metadata MessageMetadata, subscription PayloadSubscription)
+	eventHandlers
+}
+
+func newHandlers() *handlers {
+	return &handlers{
+		onError: func(err error) { fmt.Printf("ERROR: %v\n", err) },
+	}
+}
+
+func (h *handlers) OnError(callback func(err error)) {
+	h.onError = callback
+}
+
+func (h *handlers) OnWelcome(callback func(message WelcomeMessage)) {
+	h.onWelcome = callback
+}
+
+func (h *handlers) OnKeepAlive(callback func(message KeepAliveMessage)) {
+	h.onKeepAlive = callback
+}
+
+func (h *handlers) OnNotification(callback func(message NotificationMessage)) {
+	h.onNotification = callback
+}
+
+func (h *handlers) OnReconnect(callback func(message ReconnectMessage)) {
+	h.onReconnect = callback
+}
+
+func (h *handlers) OnRevoke(callback func(message RevokeMessage)) {
+	h.onRevoke = callback
+}
+
+// SetDecodingMode decides what happens to event fields that the event types do not model.
+func (h *handlers) SetDecodingMode(mode DecodingMode) {
+	h.decodingMode = mode
+}
+
+// OnUnknownField is called the first time a field that is not modeled appears in an event of a type,
+// whatever the decoding mode. Nested fields are named by their path, e.g. raid.new_field.
+func (h *handlers) OnUnknownField(callback func(event EventSubscription, field string, value json.RawMessage)) {
+	h.onUnknownField = callback
+}
+
+func (h *handlers) OnRawEvent(callback func(event string, metadata MessageMetadata, subscription PayloadSubscription)) {
+	h.onRawEvent = callback
+}
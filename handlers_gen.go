@@ -0,0 +1,527 @@
+// Code generated by eventgen from subscriptions.json. DO NOT EDIT.
+
+package twitch
+
+// eventHandlers holds the typed event callbacks, it is embedded in handlers.
+type eventHandlers struct {
+	onEventChannelUpdate                                    func(event EventChannelUpdate)
+	onEventChannelFollow                                    func(event EventChannelFollow)
+	onEventChannelSubscribe                                 func(event EventChannelSubscribe)
+	onEventChannelSubscriptionEnd                           func(event EventChannelSubscriptionEnd)
+	onEventChannelSubscriptionGift                          func(event EventChannelSubscriptionGift)
+	onEventChannelSubscriptionMessage                       func(event EventChannelSubscriptionMessage)
+	onEventChannelCheer                                     func(event EventChannelCheer)
+	onEventChannelRaid                                      func(event EventChannelRaid)
+	onEventChannelBan                                       func(event EventChannelBan)
+	onEventChannelUnban                                     func(event EventChannelUnban)
+	onEventChannelModeratorAdd                              func(event EventChannelModeratorAdd)
+	onEventChannelModeratorRemove                           func(event EventChannelModeratorRemove)
+	onEventChannelVIPAdd                                    func(event EventChannelVIPAdd)
+	onEventChannelVIPRemove                                 func(event EventChannelVIPRemove)
+	onEventChannelChannelPointsCustomRewardAdd              func(event EventChannelChannelPointsCustomRewardAdd)
+	onEventChannelChannelPointsCustomRewardUpdate           func(event EventChannelChannelPointsCustomRewardUpdate)
+	onEventChannelChannelPointsCustomRewardRemove           func(event EventChannelChannelPointsCustomRewardRemove)
+	onEventChannelChannelPointsCustomRewardRedemptionAdd    func(event EventChannelChannelPointsCustomRewardRedemptionAdd)
+	onEventChannelChannelPointsCustomRewardRedemptionUpdate func(event EventChannelChannelPointsCustomRewardRedemptionUpdate)
+	onEventChannelChannelPointsAutomaticRewardRedemptionAdd func(event EventChannelChannelPointsAutomaticRewardRedemptionAdd)
+	onEventChannelPollBegin                                 func(event EventChannelPollBegin)
+	onEventChannelPollProgress                              func(event EventChannelPollProgress)
+	onEventChannelPollEnd                                   func(event EventChannelPollEnd)
+	onEventChannelPredictionBegin                           func(event EventChannelPredictionBegin)
+	onEventChannelPredictionProgress                        func(event EventChannelPredictionProgress)
+	onEventChannelPredictionLock                            func(event EventChannelPredictionLock)
+	onEventChannelPredictionEnd                             func(event EventChannelPredictionEnd)
+	onEventDropEntitlementGrant                             func(event []EventDropEntitlementGrant)
+	onEventExtensionBitsTransactionCreate                   func(event EventExtensionBitsTransactionCreate)
+	onEventChannelGoalBegin                                 func(event EventChannelGoalBegin)
+	onEventChannelGoalProgress                              func(event EventChannelGoalProgress)
+	onEventChannelGoalEnd                                   func(event EventChannelGoalEnd)
+	onEventChannelHypeTrainBegin                            func(event EventChannelHypeTrainBegin)
+	onEventChannelHypeTrainProgress                         func(event EventChannelHypeTrainProgress)
+	onEventChannelHypeTrainEnd                              func(event EventChannelHypeTrainEnd)
+	onEventStreamOnline                                     func(event EventStreamOnline)
+	onEventStreamOffline                                    func(event EventStreamOffline)
+	onEventUserAuthorizationGrant                           func(event EventUserAuthorizationGrant)
+	onEventUserAuthorizationRevoke                          func(event EventUserAuthorizationRevoke)
+	onEventUserUpdate                                       func(event EventUserUpdate)
+	onEventChannelCharityCampaignDonate                     func(event EventChannelCharityCampaignDonate)
+	onEventChannelCharityCampaignStart                      func(event EventChannelCharityCampaignStart)
+	onEventChannelCharityCampaignProgress                   func(event EventChannelCharityCampaignProgress)
+	onEventChannelCharityCampaignStop                       func(event EventChannelCharityCampaignStop)
+	onEventChannelShieldModeBegin                           func(event EventChannelShieldModeBegin)
+	onEventChannelShieldModeEnd                             func(event EventChannelShieldModeEnd)
+	onEventChannelShoutoutCreate                            func(event EventChannelShoutoutCreate)
+	onEventChannelShoutoutReceive                           func(event EventChannelShoutoutReceive)
+	onEventChannelModerate                                  func(event EventChannelModerate)
+	onEventAutomodMessageHold                               func(event EventAutomodMessageHold)
+	onEventAutomodMessageUpdate                             func(event EventAutomodMessageUpdate)
+	onEventAutomodSettingsUpdate                            func(event EventAutomodSettingsUpdate)
+	onEventAutomodTermsUpdate                               func(event EventAutomodTermsUpdate)
+	onEventChannelChatUserMessageHold                       func(event EventChannelChatUserMessageHold)
+	onEventChannelChatUserMessageUpdate                     func(event EventChannelChatUserMessageUpdate)
+	onEventChannelChatClear                                 func(event EventChannelChatClear)
+	onEventChannelChatClearUserMessages                     func(event EventChannelChatClearUserMessages)
+	onEventChannelChatMessage                               func(event EventChannelChatMessage)
+	onEventChannelChatMessageDelete                         func(event EventChannelChatMessageDelete)
+	onEventChannelChatNotification                          func(event EventChannelChatNotification)
+	onEventChannelChatSettingsUpdate                        func(event EventChannelChatSettingsUpdate)
+	onEventChannelSuspiciousUserMessage                     func(event EventChannelSuspiciousUserMessage)
+	onEventChannelSuspiciousUserUpdate                      func(event EventChannelSuspiciousUserUpdate)
+	onEventChannelSharedChatBegin                           func(event EventChannelSharedChatBegin)
+	onEventChannelSharedChatUpdate                          func(event EventChannelSharedChatUpdate)
+	onEventChannelSharedChatEnd                             func(event EventChannelSharedChatEnd)
+	onEventUserWhisperMessage                               func(event EventUserWhisperMessage)
+	onEventChannelAdBreakBegin                              func(event EventChannelAdBreakBegin)
+	onEventChannelWarningAcknowledge                        func(event EventChannelWarningAcknowledge)
+	onEventChannelWarningSend                               func(event EventChannelWarningSend)
+	onEventChannelUnbanRequestCreate                        func(event EventChannelUnbanRequestCreate)
+	onEventChannelUnbanRequestResolve                       func(event EventChannelUnbanRequestResolve)
+	onEventConduitShardDisabled                             func(event EventConduitShardDisabled)
+}
+
+func (h *handlers) OnEventChannelUpdate(callback func(event EventChannelUpdate)) {
+	h.onEventChannelUpdate = callback
+}
+
+func (h *handlers) OnEventChannelFollow(callback func(event EventChannelFollow)) {
+	h.onEventChannelFollow = callback
+}
+
+func (h *handlers) OnEventChannelSubscribe(callback func(event EventChannelSubscribe)) {
+	h.onEventChannelSubscribe = callback
+}
+
+func (h *handlers) OnEventChannelSubscriptionEnd(callback func(event EventChannelSubscriptionEnd)) {
+	h.onEventChannelSubscriptionEnd = callback
+}
+
+func (h *handlers) OnEventChannelSubscriptionGift(callback func(event EventChannelSubscriptionGift)) {
+	h.onEventChannelSubscriptionGift = callback
+}
+
+func (h *handlers) OnEventChannelSubscriptionMessage(callback func(event EventChannelSubscriptionMessage)) {
+	h.onEventChannelSubscriptionMessage = callback
+}
+
+func (h *handlers) OnEventChannelCheer(callback func(event EventChannelCheer)) {
+	h.onEventChannelCheer = callback
+}
+
+func (h *handlers) OnEventChannelRaid(callback func(event EventChannelRaid)) {
+	h.onEventChannelRaid = callback
+}
+
+func (h *handlers) OnEventChannelBan(callback func(event EventChannelBan)) {
+	h.onEventChannelBan = callback
+}
+
+func (h *handlers) OnEventChannelUnban(callback func(event EventChannelUnban)) {
+	h.onEventChannelUnban = callback
+}
+
+func (h *handlers) OnEventChannelModeratorAdd(callback func(event EventChannelModeratorAdd)) {
+	h.onEventChannelModeratorAdd = callback
+}
+
+func (h *handlers) OnEventChannelModeratorRemove(callback func(event EventChannelModeratorRemove)) {
+	h.onEventChannelModeratorRemove = callback
+}
+
+func (h *handlers) OnEventChannelVIPAdd(callback func(event EventChannelVIPAdd)) {
+	h.onEventChannelVIPAdd = callback
+}
+
+func (h *handlers) OnEventChannelVIPRemove(callback func(event EventChannelVIPRemove)) {
+	h.onEventChannelVIPRemove = callback
+}
+
+func (h *handlers) OnEventChannelChannelPointsCustomRewardAdd(callback func(event EventChannelChannelPointsCustomRewardAdd)) {
+	h.onEventChannelChannelPointsCustomRewardAdd = callback
+}
+
+func (h *handlers) OnEventChannelChannelPointsCustomRewardUpdate(callback func(event EventChannelChannelPointsCustomRewardUpdate)) {
+	h.onEventChannelChannelPointsCustomRewardUpdate = callback
+}
+
+func (h *handlers) OnEventChannelChannelPointsCustomRewardRemove(callback func(event EventChannelChannelPointsCustomRewardRemove)) {
+	h.onEventChannelChannelPointsCustomRewardRemove = callback
+}
+
+func (h *handlers) OnEventChannelChannelPointsCustomRewardRedemptionAdd(callback func(event EventChannelChannelPointsCustomRewardRedemptionAdd)) {
+	h.onEventChannelChannelPointsCustomRewardRedemptionAdd = callback
+}
+
+func (h *handlers) OnEventChannelChannelPointsCustomRewardRedemptionUpdate(callback func(event EventChannelChannelPointsCustomRewardRedemptionUpdate)) {
+	h.onEventChannelChannelPointsCustomRewardRedemptionUpdate = callback
+}
+
+func (h *handlers) OnEventChannelChannelPointsAutomaticRewardRedemptionAdd(callback func(event EventChannelChannelPointsAutomaticRewardRedemptionAdd)) {
+	h.onEventChannelChannelPointsAutomaticRewardRedemptionAdd = callback
+}
+
+func (h *handlers) OnEventChannelPollBegin(callback func(event EventChannelPollBegin)) {
+	h.onEventChannelPollBegin = callback
+}
+
+func (h *handlers) OnEventChannelPollProgress(callback func(event EventChannelPollProgress)) {
+	h.onEventChannelPollProgress = callback
+}
+
+func (h *handlers) OnEventChannelPollEnd(callback func(event EventChannelPollEnd)) {
+	h.onEventChannelPollEnd = callback
+}
+
+func (h *handlers) OnEventChannelPredictionBegin(callback func(event EventChannelPredictionBegin)) {
+	h.onEventChannelPredictionBegin = callback
+}
+
+func (h *handlers) OnEventChannelPredictionProgress(callback func(event EventChannelPredictionProgress)) {
+	h.onEventChannelPredictionProgress = callback
+}
+
+func (h *handlers) OnEventChannelPredictionLock(callback func(event EventChannelPredictionLock)) {
+	h.onEventChannelPredictionLock = callback
+}
+
+func (h *handlers) OnEventChannelPredictionEnd(callback func(event EventChannelPredictionEnd)) {
+	h.onEventChannelPredictionEnd = callback
+}
+
+func (h *handlers) OnEventDropEntitlementGrant(callback func(event []EventDropEntitlementGrant)) {
+	h.onEventDropEntitlementGrant = callback
+}
+
+func (h *handlers) OnEventExtensionBitsTransactionCreate(callback func(event EventExtensionBitsTransactionCreate)) {
+	h.onEventExtensionBitsTransactionCreate = callback
+}
+
+func (h *handlers) OnEventChannelGoalBegin(callback func(event EventChannelGoalBegin)) {
+	h.onEventChannelGoalBegin = callback
+}
+
+func (h *handlers) OnEventChannelGoalProgress(callback func(event EventChannelGoalProgress)) {
+	h.onEventChannelGoalProgress = callback
+}
+
+func (h *handlers) OnEventChannelGoalEnd(callback func(event EventChannelGoalEnd)) {
+	h.onEventChannelGoalEnd = callback
+}
+
+func (h *handlers) OnEventChannelHypeTrainBegin(callback func(event EventChannelHypeTrainBegin)) {
+	h.onEventChannelHypeTrainBegin = callback
+}
+
+func (h *handlers) OnEventChannelHypeTrainProgress(callback func(event EventChannelHypeTrainProgress)) {
+	h.onEventChannelHypeTrainProgress = callback
+}
+
+func (h *handlers) OnEventChannelHypeTrainEnd(callback func(event EventChannelHypeTrainEnd)) {
+	h.onEventChannelHypeTrainEnd = callback
+}
+
+func (h *handlers) OnEventStreamOnline(callback func(event EventStreamOnline)) {
+	h.onEventStreamOnline = callback
+}
+
+func (h *handlers) OnEventStreamOffline(callback func(event EventStreamOffline)) {
+	h.onEventStreamOffline = callback
+}
+
+func (h *handlers) OnEventUserAuthorizationGrant(callback func(event EventUserAuthorizationGrant)) {
+	h.onEventUserAuthorizationGrant = callback
+}
+
+func (h *handlers) OnEventUserAuthorizationRevoke(callback func(event EventUserAuthorizationRevoke)) {
+	h.onEventUserAuthorizationRevoke = callback
+}
+
+func (h *handlers) OnEventUserUpdate(callback func(event EventUserUpdate)) {
+	h.onEventUserUpdate = callback
+}
+
+func (h *handlers) OnEventChannelCharityCampaignDonate(callback func(event EventChannelCharityCampaignDonate)) {
+	h.onEventChannelCharityCampaignDonate = callback
+}
+
+func (h *handlers) OnEventChannelCharityCampaignStart(callback func(event EventChannelCharityCampaignStart)) {
+	h.onEventChannelCharityCampaignStart = callback
+}
+
+func (h *handlers) OnEventChannelCharityCampaignProgress(callback func(event EventChannelCharityCampaignProgress)) {
+	h.onEventChannelCharityCampaignProgress = callback
+}
+
+func (h *handlers) OnEventChannelCharityCampaignStop(callback func(event EventChannelCharityCampaignStop)) {
+	h.onEventChannelCharityCampaignStop = callback
+}
+
+func (h *handlers) OnEventChannelShieldModeBegin(callback func(event EventChannelShieldModeBegin)) {
+	h.onEventChannelShieldModeBegin = callback
+}
+
+func (h *handlers) OnEventChannelShieldModeEnd(callback func(event EventChannelShieldModeEnd)) {
+	h.onEventChannelShieldModeEnd = callback
+}
+
+func (h *handlers) OnEventChannelShoutoutCreate(callback func(event EventChannelShoutoutCreate)) {
+	h.onEventChannelShoutoutCreate = callback
+}
+
+func (h *handlers) OnEventChannelShoutoutReceive(callback func(event EventChannelShoutoutReceive)) {
+	h.onEventChannelShoutoutReceive = callback
+}
+
+func (h *handlers) OnEventChannelModerate(callback func(event EventChannelModerate)) {
+	h.onEventChannelModerate = callback
+}
+
+func (h *handlers) OnEventAutomodMessageHold(callback func(event EventAutomodMessageHold)) {
+	h.onEventAutomodMessageHold = callback
+}
+
+func (h *handlers) OnEventAutomodMessageUpdate(callback func(event EventAutomodMessageUpdate)) {
+	h.onEventAutomodMessageUpdate = callback
+}
+
+func (h *handlers) OnEventAutomodSettingsUpdate(callback func(event EventAutomodSettingsUpdate)) {
+	h.onEventAutomodSettingsUpdate = callback
+}
+
+func (h *handlers) OnEventAutomodTermsUpdate(callback func(event EventAutomodTermsUpdate)) {
+	h.onEventAutomodTermsUpdate = callback
+}
+
+func (h *handlers) OnEventChannelChatUserMessageHold(callback func(event EventChannelChatUserMessageHold)) {
+	h.onEventChannelChatUserMessageHold = callback
+}
+
+func (h *handlers) OnEventChannelChatUserMessageUpdate(callback func(event EventChannelChatUserMessageUpdate)) {
+	h.onEventChannelChatUserMessageUpdate = callback
+}
+
+func (h *handlers) OnEventChannelChatClear(callback func(event EventChannelChatClear)) {
+	h.onEventChannelChatClear = callback
+}
+
+func (h *handlers) OnEventChannelChatClearUserMessages(callback func(event EventChannelChatClearUserMessages)) {
+	h.onEventChannelChatClearUserMessages = callback
+}
+
+func (h *handlers) OnEventChannelChatMessage(callback func(event EventChannelChatMessage)) {
+	h.onEventChannelChatMessage = callback
+}
+
+func (h *handlers) OnEventChannelChatMessageDelete(callback func(event EventChannelChatMessageDelete)) {
+	h.onEventChannelChatMessageDelete = callback
+}
+
+func (h *handlers) OnEventChannelChatNotification(callback func(event EventChannelChatNotification)) {
+	h.onEventChannelChatNotification = callback
+}
+
+func (h *handlers) OnEventChannelChatSettingsUpdate(callback func(event EventChannelChatSettingsUpdate)) {
+	h.onEventChannelChatSettingsUpdate = callback
+}
+
+func (h *handlers) OnEventChannelSuspiciousUserMessage(callback func(event EventChannelSuspiciousUserMessage)) {
+	h.onEventChannelSuspiciousUserMessage = callback
+}
+
+func (h *handlers) OnEventChannelSuspiciousUserUpdate(callback func(event EventChannelSuspiciousUserUpdate)) {
+	h.onEventChannelSuspiciousUserUpdate = callback
+}
+
+func (h *handlers) OnEventChannelSharedChatBegin(callback func(event EventChannelSharedChatBegin)) {
+	h.onEventChannelSharedChatBegin = callback
+}
+
+func (h *handlers) OnEventChannelSharedChatUpdate(callback func(event EventChannelSharedChatUpdate)) {
+	h.onEventChannelSharedChatUpdate = callback
+}
+
+func (h *handlers) OnEventChannelSharedChatEnd(callback func(event EventChannelSharedChatEnd)) {
+	h.onEventChannelSharedChatEnd = callback
+}
+
+func (h *handlers) OnEventUserWhisperMessage(callback func(event EventUserWhisperMessage)) {
+	h.onEventUserWhisperMessage = callback
+}
+
+func (h *handlers) OnEventChannelAdBreakBegin(callback func(event EventChannelAdBreakBegin)) {
+	h.onEventChannelAdBreakBegin = callback
+}
+
+func (h *handlers) OnEventChannelWarningAcknowledge(callback func(event EventChannelWarningAcknowledge)) {
+	h.onEventChannelWarningAcknowledge = callback
+}
+
+func (h *handlers) OnEventChannelWarningSend(callback func(event EventChannelWarningSend)) {
+	h.onEventChannelWarningSend = callback
+}
+
+func (h *handlers) OnEventChannelUnbanRequestCreate(callback func(event EventChannelUnbanRequestCreate)) {
+	h.onEventChannelUnbanRequestCreate = callback
+}
+
+func (h *handlers) OnEventChannelUnbanRequestResolve(callback func(event EventChannelUnbanRequestResolve)) {
+	h.onEventChannelUnbanRequestResolve = callback
+}
+
+func (h *handlers) OnEventConduitShardDisabled(callback func(event EventConduitShardDisabled)) {
+	h.onEventConduitShardDisabled = callback
+}
+
+// dispatchEvent calls the callback of the event type, it returns false if the type has none.
+func (h *handlers) dispatchEvent(event any) bool {
+	switch event := event.(type) {
+	case *EventChannelUpdate:
+		callFunc(h.onEventChannelUpdate, *event)
+	case *EventChannelFollow:
+		callFunc(h.onEventChannelFollow, *event)
+	case *EventChannelSubscribe:
+		callFunc(h.onEventChannelSubscribe, *event)
+	case *EventChannelSubscriptionEnd:
+		callFunc(h.onEventChannelSubscriptionEnd, *event)
+	case *EventChannelSubscriptionGift:
+		callFunc(h.onEventChannelSubscriptionGift, *event)
+	case *EventChannelSubscriptionMessage:
+		callFunc(h.onEventChannelSubscriptionMessage, *event)
+	case *EventChannelCheer:
+		callFunc(h.onEventChannelCheer, *event)
+	case *EventChannelRaid:
+		callFunc(h.onEventChannelRaid, *event)
+	case *EventChannelBan:
+		callFunc(h.onEventChannelBan, *event)
+	case *EventChannelUnban:
+		callFunc(h.onEventChannelUnban, *event)
+	case *EventChannelModeratorAdd:
+		callFunc(h.onEventChannelModeratorAdd, *event)
+	case *EventChannelModeratorRemove:
+		callFunc(h.onEventChannelModeratorRemove, *event)
+	case *EventChannelVIPAdd:
+		callFunc(h.onEventChannelVIPAdd, *event)
+	case *EventChannelVIPRemove:
+		callFunc(h.onEventChannelVIPRemove, *event)
+	case *EventChannelChannelPointsCustomRewardAdd:
+		callFunc(h.onEventChannelChannelPointsCustomRewardAdd, *event)
+	case *EventChannelChannelPointsCustomRewardUpdate:
+		callFunc(h.onEventChannelChannelPointsCustomRewardUpdate, *event)
+	case *EventChannelChannelPointsCustomRewardRemove:
+		callFunc(h.onEventChannelChannelPointsCustomRewardRemove, *event)
+	case *EventChannelChannelPointsCustomRewardRedemptionAdd:
+		callFunc(h.onEventChannelChannelPointsCustomRewardRedemptionAdd, *event)
+	case *EventChannelChannelPointsCustomRewardRedemptionUpdate:
+		callFunc(h.onEventChannelChannelPointsCustomRewardRedemptionUpdate, *event)
+	case *EventChannelChannelPointsAutomaticRewardRedemptionAdd:
+		callFunc(h.onEventChannelChannelPointsAutomaticRewardRedemptionAdd, *event)
+	case *EventChannelPollBegin:
+		callFunc(h.onEventChannelPollBegin, *event)
+	case *EventChannelPollProgress:
+		callFunc(h.onEventChannelPollProgress, *event)
+	case *EventChannelPollEnd:
+		callFunc(h.onEventChannelPollEnd, *event)
+	case *EventChannelPredictionBegin:
+		callFunc(h.onEventChannelPredictionBegin, *event)
+	case *EventChannelPredictionProgress:
+		callFunc(h.onEventChannelPredictionProgress, *event)
+	case *EventChannelPredictionLock:
+		callFunc(h.onEventChannelPredictionLock, *event)
+	case *EventChannelPredictionEnd:
+		callFunc(h.onEventChannelPredictionEnd, *event)
+	case *[]EventDropEntitlementGrant:
+		callFunc(h.onEventDropEntitlementGrant, *event)
+	case *EventExtensionBitsTransactionCreate:
+		callFunc(h.onEventExtensionBitsTransactionCreate, *event)
+	case *EventChannelGoalBegin:
+		callFunc(h.onEventChannelGoalBegin, *event)
+	case *EventChannelGoalProgress:
+		callFunc(h.onEventChannelGoalProgress, *event)
+	case *EventChannelGoalEnd:
+		callFunc(h.onEventChannelGoalEnd, *event)
+	case *EventChannelHypeTrainBegin:
+		callFunc(h.onEventChannelHypeTrainBegin, *event)
+	case *EventChannelHypeTrainProgress:
+		callFunc(h.onEventChannelHypeTrainProgress, *event)
+	case *EventChannelHypeTrainEnd:
+		callFunc(h.onEventChannelHypeTrainEnd, *event)
+	case *EventStreamOnline:
+		callFunc(h.onEventStreamOnline, *event)
+	case *EventStreamOffline:
+		callFunc(h.onEventStreamOffline, *event)
+	case *EventUserAuthorizationGrant:
+		callFunc(h.onEventUserAuthorizationGrant, *event)
+	case *EventUserAuthorizationRevoke:
+		callFunc(h.onEventUserAuthorizationRevoke, *event)
+	case *EventUserUpdate:
+		callFunc(h.onEventUserUpdate, *event)
+	case *EventChannelCharityCampaignDonate:
+		callFunc(h.onEventChannelCharityCampaignDonate, *event)
+	case *EventChannelCharityCampaignStart:
+		callFunc(h.onEventChannelCharityCampaignStart, *event)
+	case *EventChannelCharityCampaignProgress:
+		callFunc(h.onEventChannelCharityCampaignProgress, *event)
+	case *EventChannelCharityCampaignStop:
+		callFunc(h.onEventChannelCharityCampaignStop, *event)
+	case *EventChannelShieldModeBegin:
+		callFunc(h.onEventChannelShieldModeBegin, *event)
+	case *EventChannelShieldModeEnd:
+		callFunc(h.onEventChannelShieldModeEnd, *event)
+	case *EventChannelShoutoutCreate:
+		callFunc(h.onEventChannelShoutoutCreate, *event)
+	case *EventChannelShoutoutReceive:
+		callFunc(h.onEventChannelShoutoutReceive, *event)
+	case *EventChannelModerate:
+		callFunc(h.onEventChannelModerate, *event)
+	case *EventAutomodMessageHold:
+		callFunc(h.onEventAutomodMessageHold, *event)
+	case *EventAutomodMessageUpdate:
+		callFunc(h.onEventAutomodMessageUpdate, *event)
+	case *EventAutomodSettingsUpdate:
+		callFunc(h.onEventAutomodSettingsUpdate, *event)
+	case *EventAutomodTermsUpdate:
+		callFunc(h.onEventAutomodTermsUpdate, *event)
+	case *EventChannelChatUserMessageHold:
+		callFunc(h.onEventChannelChatUserMessageHold, *event)
+	case *EventChannelChatUserMessageUpdate:
+		callFunc(h.onEventChannelChatUserMessageUpdate, *event)
+	case *EventChannelChatClear:
+		callFunc(h.onEventChannelChatClear, *event)
+	case *EventChannelChatClearUserMessages:
+		callFunc(h.onEventChannelChatClearUserMessages, *event)
+	case *EventChannelChatMessage:
+		callFunc(h.onEventChannelChatMessage, *event)
+	case *EventChannelChatMessageDelete:
+		callFunc(h.onEventChannelChatMessageDelete, *event)
+	case *EventChannelChatNotification:
+		callFunc(h.onEventChannelChatNotification, *event)
+	case *EventChannelChatSettingsUpdate:
+		callFunc(h.onEventChannelChatSettingsUpdate, *event)
+	case *EventChannelSuspiciousUserMessage:
+		callFunc(h.onEventChannelSuspiciousUserMessage, *event)
+	case *EventChannelSuspiciousUserUpdate:
+		callFunc(h.onEventChannelSuspiciousUserUpdate, *event)
+	case *EventChannelSharedChatBegin:
+		callFunc(h.onEventChannelSharedChatBegin, *event)
+	case *EventChannelSharedChatUpdate:
+		callFunc(h.onEventChannelSharedChatUpdate, *event)
+	case *EventChannelSharedChatEnd:
+		callFunc(h.onEventChannelSharedChatEnd, *event)
+	case *EventUserWhisperMessage:
+		callFunc(h.onEventUserWhisperMessage, *event)
+	case *EventChannelAdBreakBegin:
+		callFunc(h.onEventChannelAdBreakBegin, *event)
+	case *EventChannelWarningAcknowledge:
+		callFunc(h.onEventChannelWarningAcknowledge, *event)
+	case *EventChannelWarningSend:
+		callFunc(h.onEventChannelWarningSend, *event)
+	case *EventChannelUnbanRequestCreate:
+		callFunc(h.onEventChannelUnbanRequestCreate, *event)
+	case *EventChannelUnbanRequestResolve:
+		callFunc(h.onEventChannelUnbanRequestResolve, *event)
+	case *EventConduitShardDisabled:
+		callFunc(h.onEventConduitShardDisabled, *event)
+	default:
+		return false
+	}
+	return true
+}
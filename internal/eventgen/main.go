@@ -0,0 +1,578 @@
+// Command eventgen generates the subscription types, event handlers, samples and event tests
+// of the twitch package from the subscription spec. It is run by go generate in the package directory.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	header         = "// Code generated by eventgen from %s. DO NOT EDIT.\n\n"
+	generatedTests = "events_gen_test.go"
+)
+
+// subscription is one entry of the spec.
+type subscription struct {
+	Subscription string `json:"subscription"`
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+	// Event is the type the event is decoded into, Event followed by Name when empty.
+	Event string `json:"event"`
+
+	RequiredConditions []string   `json:"required_conditions"`
+	OptionalConditions []string   `json:"optional_conditions"`
+	OneOfConditions    []string   `json:"one_of_conditions"`
+	Scopes             [][]string `json:"scopes"`
+	// Transports is user for websocket, webhook and conduit, or app for webhook and conduit.
+	Transports string `json:"transports"`
+	// Cost is authorized or unauthorized.
+	Cost string `json:"cost"`
+
+	// Struct describes the event struct, which is written by hand in events.go when it is nil.
+	Struct *eventStruct `json:"struct"`
+	// Sample and Variants are added to the samples of eventsubtest.
+	Sample   json.RawMessage            `json:"sample"`
+	Variants map[string]json.RawMessage `json:"variants"`
+}
+
+// eventStruct is the event struct generated for a subscription.
+type eventStruct struct {
+	// Embed lists the structs embedded at the start of the struct, e.g. Broadcaster.
+	Embed  []string `json:"embed"`
+	Fields []field  `json:"fields"`
+	// FromSample builds the struct and the structs of its nested objects from the sample instead.
+	FromSample bool `json:"from_sample"`
+}
+
+type field struct {
+	// JSON is the key of the field in the event.
+	JSON string `json:"json"`
+	// Name is the Go name of the field, it is made from JSON when empty.
+	Name string `json:"name"`
+	// Type is the Go type of the field, e.g. int, time.Time or *ChatMessage.
+	Type string `json:"type"`
+}
+
+func (f field) name() string {
+	if f.Name == "" {
+		return fieldName(f.JSON)
+	}
+	return f.Name
+}
+
+func (s subscription) event() string {
+	if s.Event == "" {
+		return "Event" + s.Name
+	}
+	return s.Event
+}
+
+type generator struct {
+	spec          string
+	subscriptions []subscription
+	// variants lists the sample variants of every subscription type, "" is the default sample.
+	variants map[string][]string
+	// tested holds the name and variant of the events that have a handwritten test.
+	tested map[string]bool
+}
+
+func main() {
+	specPath := flag.String("spec", "subscriptions.json", "subscription spec")
+	samplesPath := flag.String("samples", "eventsubtest/samples.json", "handwritten event samples")
+	flag.Parse()
+
+	err := run(*specPath, *samplesPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "eventgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, samplesPath string) error {
+	g, err := load(specPath, samplesPath)
+	if err != nil {
+		return err
+	}
+
+	files, err := g.generate(filepath.Dir(samplesPath))
+	if err != nil {
+		return err
+	}
+
+	for path, data := range files {
+		if data == nil {
+			err = os.Remove(path)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("could not remove %s: %w", path, err)
+			}
+			continue
+		}
+
+		err = os.WriteFile(path, data, 0o644)
+		if err != nil {
+			return fmt.Errorf("could not write %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// generate returns the content of every generated file by path, nil for files that should not exist.
+func (g *generator) generate(samplesDir string) (map[string][]byte, error) {
+	generators := map[string]func() ([]byte, error){
+		"subscriptions_gen.go": g.subscriptionsFile,
+		"handlers_gen.go":      g.handlersFile,
+		"events_gen.go":        g.eventsFile,
+		generatedTests:         g.testsFile,
+		filepath.Join(samplesDir, "samples_gen.json"): g.samplesFile,
+	}
+
+	files := map[string][]byte{}
+	for path, generate := range generators {
+		data, err := generate()
+		if err != nil {
+			return nil, fmt.Errorf("could not generate %s: %w", path, err)
+		}
+		files[path] = data
+	}
+	return files, nil
+}
+
+func load(specPath, samplesPath string) (*generator, error) {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read spec: %w", err)
+	}
+
+	g := &generator{
+		spec:     filepath.Base(specPath),
+		variants: map[string][]string{},
+	}
+	err = json.Unmarshal(data, &g.subscriptions)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse spec: %w", err)
+	}
+
+	err = g.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = os.ReadFile(samplesPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read samples: %w", err)
+	}
+	var samples map[string]json.RawMessage
+	err = json.Unmarshal(data, &samples)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse samples: %w", err)
+	}
+
+	for _, sub := range g.subscriptions {
+		for key := range samples {
+			if key == sub.Subscription {
+				g.variants[sub.Subscription] = append(g.variants[sub.Subscription], "")
+			} else if strings.HasPrefix(key, sub.Subscription+"-") {
+				g.variants[sub.Subscription] = append(g.variants[sub.Subscription], strings.TrimPrefix(key, sub.Subscription+"-"))
+			}
+		}
+
+		if sub.Sample != nil {
+			if _, ok := samples[sub.Subscription]; ok {
+				return nil, fmt.Errorf("%s has a sample in the spec and in %s", sub.Subscription, samplesPath)
+			}
+			g.variants[sub.Subscription] = append(g.variants[sub.Subscription], "")
+		}
+		for variant := range sub.Variants {
+			if _, ok := samples[sub.Subscription+"-"+variant]; ok {
+				return nil, fmt.Errorf("%s has a %s sample in the spec and in %s", sub.Subscription, variant, samplesPath)
+			}
+			g.variants[sub.Subscription] = append(g.variants[sub.Subscription], variant)
+		}
+		sort.Strings(g.variants[sub.Subscription])
+	}
+	g.tested, err = testedEvents(filepath.Dir(specPath))
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// eventTestHelpers are the test helpers that check an event is handled, called with the
+// subscription as their third argument followed by the sample variant.
+var eventTestHelpers = map[string]bool{
+	"assertSpecificEventOccured":     true,
+	"assertUnsubscribedEventOccured": true,
+}
+
+// testedEvents finds the events of the handwritten tests in the package directory, keyed by testKey.
+func testedEvents(dir string) (map[string]bool, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*_test.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	tested := map[string]bool{}
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if filepath.Base(file) == generatedTests {
+			continue
+		}
+
+		parsed, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse %s: %w", file, err)
+		}
+
+		ast.Inspect(parsed, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || len(call.Args) < 3 {
+				return true
+			}
+			helper, ok := call.Fun.(*ast.Ident)
+			if !ok || !eventTestHelpers[helper.Name] {
+				return true
+			}
+			event, ok := call.Args[2].(*ast.SelectorExpr)
+			if !ok || !strings.HasPrefix(event.Sel.Name, "Sub") {
+				return true
+			}
+
+			var variants []string
+			for _, arg := range call.Args[3:] {
+				if lit, ok := arg.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					variant, _ := strconv.Unquote(lit.Value)
+					variants = append(variants, variant)
+				}
+			}
+			tested[testKey(strings.TrimPrefix(event.Sel.Name, "Sub"), strings.Join(variants, "-"))] = true
+			return true
+		})
+	}
+	return tested, nil
+}
+
+func testKey(name, variant string) string {
+	return name + "|" + variant
+}
+
+var identifier = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+func (g *generator) validate() error {
+	subscriptions := map[string]bool{}
+	names := map[string]bool{}
+
+	for i, sub := range g.subscriptions {
+		switch {
+		case sub.Subscription == "":
+			return fmt.Errorf("entry %d has no subscription", i)
+		case !identifier.MatchString(sub.Name):
+			return fmt.Errorf("%s has an invalid name %q", sub.Subscription, sub.Name)
+		case sub.Version == "":
+			return fmt.Errorf("%s has no version", sub.Subscription)
+		case sub.Transports != "user" && sub.Transports != "app":
+			return fmt.Errorf("%s has transports %q, expected user or app", sub.Subscription, sub.Transports)
+		case costs[sub.Cost] == "":
+			return fmt.Errorf("%s has cost %q, expected authorized or unauthorized", sub.Subscription, sub.Cost)
+		case sub.Struct != nil && sub.Event != "":
+			return fmt.Errorf("%s can not generate a struct for the event type %s", sub.Subscription, sub.Event)
+		case subscriptions[sub.Subscription]:
+			return fmt.Errorf("%s is in the spec twice", sub.Subscription)
+		case names[sub.Name]:
+			return fmt.Errorf("the name %s is used twice", sub.Name)
+		}
+
+		if sub.Struct != nil {
+			err := validateStruct(sub)
+			if err != nil {
+				return err
+			}
+		}
+
+		subscriptions[sub.Subscription] = true
+		names[sub.Name] = true
+	}
+	return nil
+}
+
+var fieldType = regexp.MustCompile(`^(\[\]|\*)*([a-z][a-z0-9]*|[A-Z][A-Za-z0-9]*|time\.Time|json\.RawMessage)$`)
+
+func validateStruct(sub subscription) error {
+	if sub.Struct.FromSample {
+		if sub.Sample == nil {
+			return fmt.Errorf("%s builds its struct from a sample but has none", sub.Subscription)
+		}
+		if len(sub.Struct.Embed) > 0 || len(sub.Struct.Fields) > 0 {
+			return fmt.Errorf("%s builds its struct from a sample and can not list fields", sub.Subscription)
+		}
+		return nil
+	}
+
+	for _, embed := range sub.Struct.Embed {
+		if !identifier.MatchString(embed) {
+			return fmt.Errorf("%s embeds an invalid struct %q", sub.Subscription, embed)
+		}
+	}
+
+	keys := map[string]bool{}
+	for _, f := range sub.Struct.Fields {
+		switch {
+		case f.JSON == "":
+			return fmt.Errorf("%s has a field without a json key", sub.Subscription)
+		case !identifier.MatchString(f.name()):
+			return fmt.Errorf("%s has an invalid field name %q", sub.Subscription, f.name())
+		case !fieldType.MatchString(f.Type):
+			return fmt.Errorf("%s field %s has an invalid type %q", sub.Subscription, f.JSON, f.Type)
+		case keys[f.JSON]:
+			return fmt.Errorf("%s has the field %s twice", sub.Subscription, f.JSON)
+		}
+		keys[f.JSON] = true
+	}
+	return nil
+}
+
+func (g *generator) subscriptionsFile() ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, header, g.spec)
+	b.WriteString("package twitch\n\nvar (\n")
+
+	group := ""
+	for i, sub := range g.subscriptions {
+		if prefix := subscriptionGroup(sub.Subscription); prefix != group {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			group = prefix
+		}
+		fmt.Fprintf(&b, "Sub%s EventSubscription = %q\n", sub.Name, sub.Subscription)
+	}
+
+	b.WriteString("\nsubMetadata = map[EventSubscription]subscriptionMetadata{\n")
+	for _, sub := range g.subscriptions {
+		fmt.Fprintf(&b, "Sub%s: {\n", sub.Name)
+		fmt.Fprintf(&b, "Version: %q,\n", sub.Version)
+		fmt.Fprintf(&b, "EventGen: zeroPtrGen[%s](),\n", sub.event())
+		writeStrings(&b, "RequiredConditions", sub.RequiredConditions)
+		writeStrings(&b, "OptionalConditions", sub.OptionalConditions)
+		writeStrings(&b, "OneOfConditions", sub.OneOfConditions)
+		writeScopes(&b, sub.Scopes)
+		fmt.Fprintf(&b, "Transports: %sTransports,\n", sub.Transports)
+		fmt.Fprintf(&b, "Cost: %s,\n", costs[sub.Cost])
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n)\n")
+
+	return format.Source(b.Bytes())
+}
+
+var costs = map[string]string{
+	"authorized":   "CostAuthorized",
+	"unauthorized": "CostUnauthorized",
+}
+
+// subscriptionGroup is the subscription without its last part, used to group the Sub variables.
+func subscriptionGroup(subscription string) string {
+	if i := strings.LastIndex(subscription, "."); i >= 0 {
+		return subscription[:i]
+	}
+	return subscription
+}
+
+func writeStrings(b *bytes.Buffer, field string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "%s: []string{%s},\n", field, quoteAll(values))
+}
+
+func writeScopes(b *bytes.Buffer, scopes [][]string) {
+	switch len(scopes) {
+	case 0:
+		return
+	case 1:
+		fmt.Fprintf(b, "Scopes: [][]string{{%s}},\n", quoteAll(scopes[0]))
+		return
+	}
+
+	b.WriteString("Scopes: [][]string{\n")
+	for _, alternatives := range scopes {
+		fmt.Fprintf(b, "{%s},\n", quoteAll(alternatives))
+	}
+	b.WriteString("},\n")
+}
+
+func quoteAll(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func (g *generator) handlersFile() ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, header, g.spec)
+	b.WriteString("package twitch\n\n")
+
+	b.WriteString("// eventHandlers holds the typed event callbacks, it is embedded in handlers.\ntype eventHandlers struct {\n")
+	for _, sub := range g.subscriptions {
+		fmt.Fprintf(&b, "onEvent%s func(event %s)\n", sub.Name, sub.event())
+	}
+	b.WriteString("}\n")
+
+	for _, sub := range g.subscriptions {
+		fmt.Fprintf(&b, "\nfunc (h *handlers) OnEvent%s(callback func(event %s)) {\nh.onEvent%s = callback\n}\n", sub.Name, sub.event(), sub.Name)
+	}
+
+	b.WriteString("\n// dispatchEvent calls the callback of the event type, it returns false if the type has none.\n")
+	b.WriteString("func (h *handlers) dispatchEvent(event any) bool {\nswitch event := event.(type) {\n")
+	for _, sub := range g.subscriptions {
+		fmt.Fprintf(&b, "case *%s:\ncallFunc(h.onEvent%s, *event)\n", sub.event(), sub.Name)
+	}
+	b.WriteString("default:\nreturn false\n}\nreturn true\n}\n")
+
+	return format.Source(b.Bytes())
+}
+
+func (g *generator) eventsFile() ([]byte, error) {
+	var structs bytes.Buffer
+	for _, sub := range g.subscriptions {
+		switch {
+		case sub.Struct == nil:
+			continue
+		case sub.Struct.FromSample:
+			definition, err := structFromSample(sub.event(), sub.Sample)
+			if err != nil {
+				return nil, fmt.Errorf("could not build %s from its sample: %w", sub.event(), err)
+			}
+			structs.WriteString(definition)
+		default:
+			structs.WriteString(sub.Struct.definition(sub.event()))
+		}
+	}
+	if structs.Len() == 0 {
+		return nil, nil
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, header, g.spec)
+	b.WriteString("package twitch\n\n")
+	for _, pkg := range []string{"encoding/json", "time"} {
+		if bytes.Contains(structs.Bytes(), []byte(pkg[strings.LastIndex(pkg, "/")+1:]+".")) {
+			fmt.Fprintf(&b, "import %q\n", pkg)
+		}
+	}
+	b.Write(structs.Bytes())
+
+	return format.Source(b.Bytes())
+}
+
+// definition writes the struct with Unmodeled after the embedded structs.
+func (s *eventStruct) definition(name string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\ntype %s struct {\n", name)
+	for _, embed := range s.Embed {
+		fmt.Fprintf(&b, "%s\n", embed)
+	}
+	b.WriteString("Unmodeled\n")
+	if len(s.Fields) > 0 {
+		b.WriteString("\n")
+	}
+	for _, f := range s.Fields {
+		fmt.Fprintf(&b, "%s %s `json:\"%s\"`\n", f.name(), f.Type, f.JSON)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// testsFile writes a test for every event and sample variant without a handwritten test.
+func (g *generator) testsFile() ([]byte, error) {
+	var tests bytes.Buffer
+	for _, sub := range g.subscriptions {
+		for _, variant := range g.variants[sub.Subscription] {
+			if g.tested[testKey(sub.Name, variant)] {
+				continue
+			}
+
+			// App events can't be subscribed to over a websocket
+			helper := "assertSpecificEventOccured"
+			if sub.Transports == "app" {
+				helper = "assertUnsubscribedEventOccured"
+			}
+
+			name := "TestEvent" + sub.Name + camelCase(variant)
+			args := "twitch.Sub" + sub.Name
+			if variant != "" {
+				args += fmt.Sprintf(", %q", variant)
+			}
+
+			fmt.Fprintf(&tests, `
+func %s(t *testing.T) {
+	t.Parallel()
+
+	%s(t, func(client *twitch.Client, ch chan struct{}) {
+		client.OnEvent%s(func(event %s) {
+			close(ch)
+		})
+	}, %s)
+}
+`, name, helper, sub.Name, qualify(sub.event()), args)
+		}
+	}
+	if tests.Len() == 0 {
+		return nil, nil
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, header, g.spec)
+	b.WriteString("package twitch_test\n\nimport (\n\"testing\"\n\n\"github.com/joeyak/go-twitch-eventsub/v3\"\n)\n")
+	b.Write(tests.Bytes())
+
+	return format.Source(b.Bytes())
+}
+
+var typeName = regexp.MustCompile(`^((?:\[\]|\*)*)([A-Z])`)
+
+// qualify prefixes the event type with the package name for use in the tests.
+func qualify(event string) string {
+	return typeName.ReplaceAllString(event, "${1}twitch.${2}")
+}
+
+func camelCase(value string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func (g *generator) samplesFile() ([]byte, error) {
+	samples := map[string]json.RawMessage{}
+	for _, sub := range g.subscriptions {
+		if sub.Sample != nil {
+			samples[sub.Subscription] = sub.Sample
+		}
+		for variant, sample := range sub.Variants {
+			samples[sub.Subscription+"-"+variant] = sample
+		}
+	}
+
+	data, err := json.MarshalIndent(samples, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
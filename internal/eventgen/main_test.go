@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGeneratedFilesUpToDate fails when subscriptions.json was changed without running go generate.
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	root := filepath.Join("..", "..")
+
+	g, err := load(filepath.Join(root, "subscriptions.json"), filepath.Join(root, "eventsubtest", "samples.json"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	files, err := g.generate("eventsubtest")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for path, expected := range files {
+		actual, err := os.ReadFile(filepath.Join(root, path))
+		if expected == nil {
+			assert.True(t, errors.Is(err, os.ErrNotExist), "%s should not exist, run go generate", path)
+			continue
+		}
+		if assert.NoError(t, err) {
+			assert.True(t, bytes.Equal(expected, actual), "%s is out of date, run go generate", path)
+		}
+	}
+}
+
+func TestTestedEvents(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"connEvent_test.go": `package twitch_test
+
+func TestEventStreamOnline(t *testing.T) {
+	assertSpecificEventOccured(t, register, twitch.SubStreamOnline)
+}
+
+func TestEventChannelChatNotificationRaid(t *testing.T) {
+	assertSpecificEventOccured(t, register, twitch.SubChannelChatNotification, "raid")
+}
+
+func TestEventUserAuthorizationGrant(t *testing.T) {
+	assertUnsubscribedEventOccured(t, register, twitch.SubUserAuthorizationGrant)
+}
+
+func TestOther(t *testing.T) {
+	assertEventOccured(t, twitch.SubStreamOffline)
+}
+`,
+		generatedTests: `package twitch_test
+
+func TestEventStreamOffline(t *testing.T) {
+	assertSpecificEventOccured(t, register, twitch.SubStreamOffline)
+}
+`,
+	}
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644)
+		if !assert.NoError(t, err) {
+			return
+		}
+	}
+
+	tested, err := testedEvents(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		testKey("StreamOnline", ""):                true,
+		testKey("UserAuthorizationGrant", ""):      true,
+		testKey("ChannelChatNotification", "raid"): true,
+	}, tested)
+}
+
+func TestStructFromSample(t *testing.T) {
+	definition, err := structFromSample("EventExample", []byte(`{
+		"broadcaster_user_id": "1", "broadcaster_user_login": "a", "broadcaster_user_name": "A",
+		"reward_id": "2", "count": 3, "ratio": 0.5, "active": true, "started_at": "2024-01-01T00:00:00Z",
+		"tags": ["a"], "items": [{"name": "b"}], "details": {"text": "c"}, "extra": null
+	}`))
+	assert.NoError(t, err)
+
+	expected := `
+type EventExample struct {
+Broadcaster
+Unmodeled
+
+RewardID string ` + "`json:\"reward_id\"`" + `
+Count int ` + "`json:\"count\"`" + `
+Ratio float64 ` + "`json:\"ratio\"`" + `
+Active bool ` + "`json:\"active\"`" + `
+StartedAt time.Time ` + "`json:\"started_at\"`" + `
+Tags []string ` + "`json:\"tags\"`" + `
+Items []EventExampleItems ` + "`json:\"items\"`" + `
+Details *EventExampleDetails ` + "`json:\"details\"`" + `
+Extra json.RawMessage ` + "`json:\"extra\"`" + `
+}
+
+type EventExampleItems struct {
+Name string ` + "`json:\"name\"`" + `
+}
+
+type EventExampleDetails struct {
+Text string ` + "`json:\"text\"`" + `
+}
+`
+	assert.Equal(t, expected, definition)
+}
+
+func TestEventStructDefinition(t *testing.T) {
+	var sub subscription
+	err := json.Unmarshal([]byte(`{
+		"struct": {
+			"embed": ["User", "Broadcaster"],
+			"fields": [
+				{"json": "bits", "type": "int"},
+				{"json": "power_up", "name": "PowerUp", "type": "*BitsPowerUp"},
+				{"json": "used_at", "type": "time.Time"}
+			]
+		}
+	}`), &sub)
+	assert.NoError(t, err)
+
+	expected := `
+type EventExample struct {
+User
+Broadcaster
+Unmodeled
+
+Bits int ` + "`json:\"bits\"`" + `
+PowerUp *BitsPowerUp ` + "`json:\"power_up\"`" + `
+UsedAt time.Time ` + "`json:\"used_at\"`" + `
+}
+`
+	assert.Equal(t, expected, sub.Struct.definition("EventExample"))
+}
+
+func TestValidate(t *testing.T) {
+	valid := subscription{Subscription: "a.b", Name: "AB", Version: "1", Transports: "user", Cost: "authorized"}
+
+	testCases := []struct {
+		Name   string
+		Modify func(sub *subscription)
+		Err    string
+	}{
+		{"Valid", func(sub *subscription) {}, ""},
+		{"Name", func(sub *subscription) { sub.Name = "a.b" }, "invalid name"},
+		{"Version", func(sub *subscription) { sub.Version = "" }, "no version"},
+		{"Transports", func(sub *subscription) { sub.Transports = "all" }, "expected user or app"},
+		{"Cost", func(sub *subscription) { sub.Cost = "free" }, "expected authorized or unauthorized"},
+		{"SampleStruct", func(sub *subscription) { sub.Struct = &eventStruct{FromSample: true} }, "has none"},
+		{"FieldType", func(sub *subscription) {
+			sub.Struct = &eventStruct{Fields: []field{{JSON: "bits", Type: "int; bad"}}}
+		}, "invalid type"},
+		{"FieldTwice", func(sub *subscription) {
+			sub.Struct = &eventStruct{Fields: []field{{JSON: "bits", Type: "int"}, {JSON: "bits", Type: "string"}}}
+		}, "twice"},
+		{"StructForEvent", func(sub *subscription) {
+			sub.Event = "EventOther"
+			sub.Struct = &eventStruct{}
+		}, "can not generate"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			sub := valid
+			tc.Modify(&sub)
+
+			err := (&generator{subscriptions: []subscription{sub}}).validate()
+			if tc.Err == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.True(t, strings.Contains(err.Error(), tc.Err), err.Error())
+			}
+		})
+	}
+
+	err := (&generator{subscriptions: []subscription{valid, valid}}).validate()
+	assert.ErrorContains(t, err, "twice")
+}
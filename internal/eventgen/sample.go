@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// member is a key of a json object in the order of the sample.
+type member struct {
+	key   string
+	value any
+}
+
+// embeds are the shared structs used for fields that always come together.
+var embeds = []struct {
+	name string
+	keys []string
+}{
+	{"Broadcaster", []string{"broadcaster_user_id", "broadcaster_user_login", "broadcaster_user_name"}},
+	{"Moderator", []string{"moderator_user_id", "moderator_user_login", "moderator_user_name"}},
+	{"User", []string{"user_id", "user_login", "user_name"}},
+}
+
+// structFromSample writes the struct of an event and the structs of its nested objects from a sample.
+func structFromSample(name string, sample json.RawMessage) (string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(sample))
+	decoder.UseNumber()
+
+	value, err := decodeOrdered(decoder)
+	if err != nil {
+		return "", err
+	}
+	object, ok := value.([]member)
+	if !ok {
+		return "", fmt.Errorf("sample is not an object")
+	}
+
+	var b strings.Builder
+	writeStruct(&b, name, object, true)
+	return b.String(), nil
+}
+
+func writeStruct(b *strings.Builder, name string, object []member, event bool) {
+	var nested strings.Builder
+
+	fmt.Fprintf(b, "\ntype %s struct {\n", name)
+
+	skip := map[string]bool{}
+	for _, embed := range embeds {
+		if hasKeys(object, embed.keys) {
+			fmt.Fprintf(b, "%s\n", embed.name)
+			for _, key := range embed.keys {
+				skip[key] = true
+			}
+		}
+	}
+	if event {
+		b.WriteString("Unmodeled\n\n")
+	} else if len(skip) > 0 {
+		b.WriteString("\n")
+	}
+
+	for _, m := range object {
+		if skip[m.key] {
+			continue
+		}
+		field := fieldName(m.key)
+		fmt.Fprintf(b, "%s %s `json:\"%s\"`\n", field, goType(&nested, name+field, m.value), m.key)
+	}
+	b.WriteString("}\n")
+	b.WriteString(nested.String())
+}
+
+func goType(nested *strings.Builder, name string, value any) string {
+	switch v := value.(type) {
+	case []member:
+		writeStruct(nested, name, v, false)
+		return "*" + name
+	case []any:
+		if len(v) == 0 {
+			return "[]json.RawMessage"
+		}
+		return "[]" + strings.TrimPrefix(goType(nested, name, v[0]), "*")
+	case string:
+		if _, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return "time.Time"
+		}
+		return "string"
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			return "float64"
+		}
+		return "int"
+	case bool:
+		return "bool"
+	}
+	return "json.RawMessage"
+}
+
+func hasKeys(object []member, keys []string) bool {
+	for _, key := range keys {
+		found := false
+		for _, m := range object {
+			if m.key == key {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldName turns a json key such as broadcaster_user_id into BroadcasterUserID.
+func fieldName(key string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(key, "_") {
+		if part == "" {
+			continue
+		}
+		if part == "id" {
+			b.WriteString("ID")
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// decodeOrdered decodes a json value keeping the order of object keys.
+func decodeOrdered(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		var object []member
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, member{key: key.(string), value: value})
+		}
+		_, err = decoder.Token()
+		return object, err
+	case json.Delim('['):
+		array := []any{}
+		for decoder.More() {
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err = decoder.Token()
+		return array, err
+	}
+	return token, nil
+}
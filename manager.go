@@ -0,0 +1,336 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const defaultReconnectDelay = 5 * time.Second
+
+var (
+	ErrTenantExists    = fmt.Errorf("tenant already exists")
+	ErrTenantNotFound  = fmt.Errorf("tenant not found")
+	ErrManagerStarted  = fmt.Errorf("manager is already connected")
+	ErrMissingTenantID = fmt.Errorf("tenant user id is required")
+)
+
+// Tenant is a user connected through a Manager with its own user access token.
+type Tenant struct {
+	UserID      string
+	ClientID    string
+	AccessToken string
+
+	// Requests are subscribed on every new session of the tenant.
+	// The ClientID and AccessToken of the tenant are used when they are not set on a request.
+	Requests []SubscribeRequest
+}
+
+// Manager keeps one Client per tenant and routes their messages through a single set of handlers
+// annotated with the tenant's user id. Each tenant connects on its own, so a connection or
+// subscription failure is reported for that tenant without affecting the others, and a dropped
+// connection is reconnected after ReconnectDelay.
+type Manager struct {
+	Address string
+	// Subscriber holds the settings of the subscription clients. Every tenant gets a client
+	// with its own rate limit, so a rate limited token does not hold back the other tenants.
+	Subscriber *SubscriptionClient
+
+	// ReconnectDelay is how long to wait before connecting a tenant again after its connection dropped.
+	ReconnectDelay time.Duration
+	// Clock is used for the reconnect delay and is given to the tenant clients.
+	Clock Clock
+
+	mu      sync.Mutex
+	ctx     context.Context
+	cancel  context.CancelFunc
+	tenants map[string]*tenantClient
+	wg      sync.WaitGroup
+
+	onError        func(userID string, err error)
+	onWelcome      func(userID string, message WelcomeMessage)
+	onNotification func(userID string, message NotificationMessage)
+	onRevoke       func(userID string, message RevokeMessage)
+	onRawEvent     func(userID string, event string, metadata MessageMetadata, subscription PayloadSubscription)
+	onClient       func(userID string, client *Client)
+}
+
+type tenantClient struct {
+	tenant  Tenant
+	client  *Client
+	manager *SubscriptionManager
+	cancel  context.CancelFunc
+
+	// ready is set once OnClient was called, the tenant is not started before
+	ready bool
+}
+
+func NewManager() *Manager {
+	return NewManagerWithUrl(twitchWebsocketUrl)
+}
+
+func NewManagerWithUrl(url string) *Manager {
+	return &Manager{
+		Address:        url,
+		Subscriber:     NewSubscriptionClient(),
+		ReconnectDelay: defaultReconnectDelay,
+		Clock:          SystemClock,
+		tenants:        map[string]*tenantClient{},
+
+		onError: func(userID string, err error) { fmt.Printf("ERROR[%s]: %v\n", userID, err) },
+	}
+}
+
+func (m *Manager) OnError(callback func(userID string, err error)) {
+	m.onError = callback
+}
+
+func (m *Manager) OnWelcome(callback func(userID string, message WelcomeMessage)) {
+	m.onWelcome = callback
+}
+
+func (m *Manager) OnNotification(callback func(userID string, message NotificationMessage)) {
+	m.onNotification = callback
+}
+
+func (m *Manager) OnRevoke(callback func(userID string, message RevokeMessage)) {
+	m.onRevoke = callback
+}
+
+func (m *Manager) OnRawEvent(callback func(userID string, event string, metadata MessageMetadata, subscription PayloadSubscription)) {
+	m.onRawEvent = callback
+}
+
+// OnClient is called with the client of every tenant when it is added, before it connects.
+// It can be used to register typed event handlers for the tenant.
+func (m *Manager) OnClient(callback func(userID string, client *Client)) {
+	m.onClient = callback
+}
+
+func (m *Manager) Connect() error {
+	return m.ConnectWithContext(context.Background())
+}
+
+// ConnectWithContext connects every tenant and blocks until the manager is closed.
+func (m *Manager) ConnectWithContext(ctx context.Context) error {
+	m.mu.Lock()
+	if m.ctx != nil {
+		m.mu.Unlock()
+		return ErrManagerStarted
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	m.ctx, m.cancel = ctx, cancel
+	for _, tenant := range m.tenants {
+		if tenant.ready {
+			m.start(tenant)
+		}
+	}
+	m.mu.Unlock()
+
+	<-ctx.Done()
+	m.wg.Wait()
+
+	// Forget the context so the manager can connect again
+	m.mu.Lock()
+	cancel()
+	m.ctx, m.cancel = nil, nil
+	m.mu.Unlock()
+	return nil
+}
+
+// AddTenant adds a tenant and connects it if the manager is connected.
+func (m *Manager) AddTenant(tenant Tenant) error {
+	if tenant.UserID == "" {
+		return ErrMissingTenantID
+	}
+
+	tc, err := m.newTenantClient(tenant)
+	if err != nil {
+		return fmt.Errorf("could not add tenant %s: %w", tenant.UserID, err)
+	}
+
+	m.mu.Lock()
+	if _, ok := m.tenants[tenant.UserID]; ok {
+		m.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrTenantExists, tenant.UserID)
+	}
+	m.tenants[tenant.UserID] = tc
+	m.mu.Unlock()
+
+	// OnClient is called without the lock so it can use the manager
+	if m.onClient != nil {
+		m.onClient(tenant.UserID, tc.client)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tc.ready = true
+	if m.tenants[tenant.UserID] == tc && m.ctx != nil && m.ctx.Err() == nil {
+		m.start(tc)
+	}
+	return nil
+}
+
+// RemoveTenant disconnects the tenant and forgets it.
+func (m *Manager) RemoveTenant(userID string) error {
+	m.mu.Lock()
+	tc, ok := m.tenants[userID]
+	delete(m.tenants, userID)
+	m.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrTenantNotFound, userID)
+	}
+
+	if tc.cancel != nil {
+		tc.cancel()
+	}
+	return tc.client.Close()
+}
+
+// Tenants returns the user ids of the tenants sorted.
+func (m *Manager) Tenants() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	userIDs := make([]string, 0, len(m.tenants))
+	for userID := range m.tenants {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+	return userIDs
+}
+
+// Client returns the client of a tenant.
+func (m *Manager) Client(userID string) (*Client, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tc, ok := m.tenants[userID]
+	if !ok {
+		return nil, false
+	}
+	return tc.client, true
+}
+
+// Status returns the subscription status of a tenant.
+func (m *Manager) Status(userID string) []SubscriptionStatus {
+	m.mu.Lock()
+	tc, ok := m.tenants[userID]
+	m.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+	return tc.manager.Status()
+}
+
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	if m.cancel != nil {
+		m.cancel()
+	}
+	tenants := make([]*tenantClient, 0, len(m.tenants))
+	for _, tc := range m.tenants {
+		tenants = append(tenants, tc)
+	}
+	m.mu.Unlock()
+
+	var errs []error
+	for _, tc := range tenants {
+		err := tc.client.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", tc.tenant.UserID, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("could not close tenant connections: %v", errs)
+	}
+	return nil
+}
+
+func (m *Manager) newTenantClient(tenant Tenant) (*tenantClient, error) {
+	userID := tenant.UserID
+
+	client := NewClientWithUrl(m.Address)
+	client.SetClock(m.Clock)
+	client.OnError(func(err error) { m.onError(userID, err) })
+	client.OnRawEvent(func(event string, metadata MessageMetadata, subscription PayloadSubscription) {
+		if m.onRawEvent != nil {
+			m.onRawEvent(userID, event, metadata, subscription)
+		}
+	})
+	client.OnWelcome(func(message WelcomeMessage) {
+		if m.onWelcome != nil {
+			m.onWelcome(userID, message)
+		}
+	})
+	client.OnNotification(func(message NotificationMessage) {
+		if m.onNotification != nil {
+			m.onNotification(userID, message)
+		}
+	})
+	client.OnRevoke(func(message RevokeMessage) {
+		if m.onRevoke != nil {
+			m.onRevoke(userID, message)
+		}
+	})
+
+	manager := NewSubscriptionManager(client)
+	manager.Subscriber = m.Subscriber.withOwnRateLimit()
+	manager.QueueOverBudget = true
+
+	for _, request := range tenant.Requests {
+		if request.ClientID == "" {
+			request.ClientID = tenant.ClientID
+		}
+		if request.AccessToken == "" {
+			request.AccessToken = tenant.AccessToken
+		}
+
+		err := manager.Add(context.Background(), request)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &tenantClient{
+		tenant:  tenant,
+		client:  client,
+		manager: manager,
+	}, nil
+}
+
+// start connects the tenant in the background, m.mu must be held.
+func (m *Manager) start(tc *tenantClient) {
+	var ctx context.Context
+	ctx, tc.cancel = context.WithCancel(m.ctx)
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.run(ctx, tc)
+	}()
+}
+
+func (m *Manager) run(ctx context.Context, tc *tenantClient) {
+	for {
+		err := tc.client.ConnectWithContext(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err == nil {
+			err = fmt.Errorf("connection closed")
+		}
+		m.onError(tc.tenant.UserID, fmt.Errorf("tenant connection dropped, reconnecting in %s: %w", m.ReconnectDelay, err))
+
+		if sleepContext(ctx, m.Clock, m.ReconnectDelay) != nil {
+			return
+		}
+	}
+}
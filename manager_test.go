@@ -0,0 +1,185 @@
+package twitch_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManagerIsolatesTenants(t *testing.T) {
+	t.Parallel()
+
+	url, _ := newScriptedServer(t)
+	helix := &fakeHelixSubscriptions{maxTotalCost: 10}
+	helixServer := httptest.NewServer(helix)
+	defer helixServer.Close()
+
+	manager := twitch.NewManagerWithUrl(url)
+	manager.Subscriber = newTestSubscriptionClient(helixServer.URL)
+
+	var mu sync.Mutex
+	welcomes := map[string]bool{}
+	failed := map[string]bool{}
+	manager.OnWelcome(func(userID string, message twitch.WelcomeMessage) {
+		mu.Lock()
+		defer mu.Unlock()
+		welcomes[userID] = true
+	})
+	manager.OnError(func(userID string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		failed[userID] = true
+	})
+
+	assert.NoError(t, manager.AddTenant(twitch.Tenant{
+		UserID:      "1",
+		AccessToken: "token",
+		Requests:    []twitch.SubscribeRequest{onlineRequest("1")},
+	}))
+	assert.ErrorIs(t, manager.AddTenant(twitch.Tenant{UserID: "1"}), twitch.ErrTenantExists)
+
+	go manager.Connect()
+	defer manager.Close()
+
+	assert.NoError(t, manager.AddTenant(twitch.Tenant{
+		UserID:      "2",
+		AccessToken: "invalid",
+		Requests:    []twitch.SubscribeRequest{onlineRequest("2")},
+	}), "tenants can be added while connected")
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return welcomes["1"] && welcomes["2"] && failed["2"]
+	}, time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	assert.False(t, failed["1"], "a bad token only affects its own tenant")
+	mu.Unlock()
+
+	assert.Eventually(t, func() bool {
+		status := manager.Status("1")
+		return len(status) == 1 && status[0].Subscribed
+	}, time.Second, 10*time.Millisecond)
+	assert.False(t, manager.Status("2")[0].Subscribed)
+
+	assert.Equal(t, []string{"1", "2"}, manager.Tenants())
+	assert.NoError(t, manager.RemoveTenant("2"))
+	assert.Equal(t, []string{"1"}, manager.Tenants())
+	assert.ErrorIs(t, manager.RemoveTenant("2"), twitch.ErrTenantNotFound)
+}
+
+func TestManagerRateLimitsTenantsSeparately(t *testing.T) {
+	t.Parallel()
+
+	url, _ := newScriptedServer(t)
+	helix := &fakeHelixSubscriptions{maxTotalCost: 10}
+	limited := make(chan struct{}, 10)
+	helixServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer limited" {
+			w.Header().Set("Ratelimit-Limit", "800")
+			w.Header().Set("Ratelimit-Remaining", "0")
+			w.Header().Set("Ratelimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+			w.WriteHeader(http.StatusTooManyRequests)
+			limited <- struct{}{}
+			return
+		}
+		helix.ServeHTTP(w, r)
+	}))
+	defer helixServer.Close()
+
+	manager := twitch.NewManagerWithUrl(url)
+	manager.Subscriber = newTestSubscriptionClient(helixServer.URL)
+	manager.OnError(func(userID string, err error) {})
+
+	assert.NoError(t, manager.AddTenant(twitch.Tenant{
+		UserID:      "1",
+		AccessToken: "limited",
+		Requests:    []twitch.SubscribeRequest{onlineRequest("1")},
+	}))
+
+	go manager.Connect()
+	defer manager.Close()
+
+	select {
+	case <-limited:
+	case <-time.After(time.Second):
+		t.Fatal("the limited tenant did not subscribe")
+	}
+
+	assert.NoError(t, manager.AddTenant(twitch.Tenant{
+		UserID:      "2",
+		AccessToken: "token",
+		Requests:    []twitch.SubscribeRequest{onlineRequest("2")},
+	}))
+
+	assert.Eventually(t, func() bool {
+		status := manager.Status("2")
+		return len(status) == 1 && status[0].Subscribed
+	}, time.Second, 10*time.Millisecond, "a rate limited token does not hold back other tenants")
+	assert.False(t, manager.Status("1")[0].Subscribed)
+}
+
+func TestManagerOnClientUsesManager(t *testing.T) {
+	t.Parallel()
+
+	url, _ := newScriptedServer(t)
+	manager := twitch.NewManagerWithUrl(url)
+
+	manager.OnClient(func(userID string, client *twitch.Client) {
+		tenantClient, ok := manager.Client(userID)
+		assert.True(t, ok)
+		assert.Same(t, client, tenantClient)
+		assert.Equal(t, []string{userID}, manager.Tenants())
+		assert.Empty(t, manager.Status(userID))
+	})
+
+	added := make(chan error, 1)
+	go func() { added <- manager.AddTenant(twitch.Tenant{UserID: "1"}) }()
+
+	select {
+	case err := <-added:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("AddTenant did not return")
+	}
+}
+
+func TestManagerConnectsAgainAfterClose(t *testing.T) {
+	t.Parallel()
+
+	url, _ := newScriptedServer(t)
+	manager := twitch.NewManagerWithUrl(url)
+
+	welcomes := make(chan string, 2)
+	manager.OnWelcome(func(userID string, message twitch.WelcomeMessage) {
+		welcomes <- userID
+	})
+	assert.NoError(t, manager.AddTenant(twitch.Tenant{UserID: "1"}))
+
+	for i := 0; i < 2; i++ {
+		done := make(chan error, 1)
+		go func() { done <- manager.Connect() }()
+
+		select {
+		case userID := <-welcomes:
+			assert.Equal(t, "1", userID)
+		case <-time.After(time.Second):
+			t.Fatalf("tenant was not connected on connect %d", i+1)
+		}
+
+		assert.NoError(t, manager.Close())
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("Connect did not return after Close")
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const maxConnectionsPerToken = 3
+
+var (
+	ErrPoolFull      = fmt.Errorf("all pool connections are full")
+	ErrPoolConnected = fmt.Errorf("pool is already connected")
+)
+
+// Pool spreads subscriptions over several websocket connections.
+// Each subscription is placed on a connection with room in its budget, and new
+// connections are opened as needed up to MaxConnections. Callbacks registered on
+// the pool are shared by every connection. When a connection drops, its
+// subscriptions are placed on the remaining connections.
+type Pool struct {
+	*handlers
+
+	Address        string
+	MaxConnections int
+	Subscriber     *SubscriptionClient
+
+	mu          sync.Mutex
+	ctx         context.Context
+	cancel      context.CancelFunc
+	connections []*poolConnection
+	pending     []SubscribeRequest
+}
+
+type poolConnection struct {
+	client  *Client
+	manager *SubscriptionManager
+
+	ready     chan struct{}
+	readyOnce sync.Once
+	done      chan struct{}
+}
+
+func NewPool() *Pool {
+	return NewPoolWithUrl(twitchWebsocketUrl)
+}
+
+func NewPoolWithUrl(url string) *Pool {
+	return &Pool{
+		handlers:       newHandlers(),
+		Address:        url,
+		MaxConnections: maxConnectionsPerToken,
+		Subscriber:     NewSubscriptionClient(),
+	}
+}
+
+func (p *Pool) Connect() error {
+	return p.ConnectWithContext(context.Background())
+}
+
+// ConnectWithContext places the subscriptions added so far and blocks until the pool is closed.
+func (p *Pool) ConnectWithContext(ctx context.Context) error {
+	p.mu.Lock()
+	if p.ctx != nil {
+		p.mu.Unlock()
+		return ErrPoolConnected
+	}
+	p.ctx, p.cancel = context.WithCancel(ctx)
+	pending := p.pending
+	p.pending = nil
+	p.mu.Unlock()
+
+	for _, request := range pending {
+		err := p.Subscribe(p.ctx, request)
+		if err != nil {
+			p.onError(err)
+		}
+	}
+
+	<-p.ctx.Done()
+	return nil
+}
+
+// Subscribe places the subscription on a connection with room for it.
+// Subscriptions made before connecting are placed once the pool connects.
+func (p *Pool) Subscribe(ctx context.Context, request SubscribeRequest) error {
+	p.mu.Lock()
+	if p.ctx == nil {
+		p.pending = append(p.pending, request)
+		p.mu.Unlock()
+		return nil
+	}
+	connections := append([]*poolConnection(nil), p.connections...)
+	p.mu.Unlock()
+
+	for _, connection := range connections {
+		if !connection.hasRoom(request.Event) {
+			continue
+		}
+
+		err := connection.manager.Add(ctx, request)
+		var budgetErr *BudgetExceededError
+		if errors.As(err, &budgetErr) {
+			continue
+		}
+		return err
+	}
+
+	connection, err := p.open()
+	if err != nil {
+		return err
+	}
+	return connection.manager.Add(ctx, request)
+}
+
+// Clients returns the clients of the open connections.
+func (p *Pool) Clients() []*Client {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	clients := make([]*Client, len(p.connections))
+	for i, connection := range p.connections {
+		clients[i] = connection.client
+	}
+	return clients
+}
+
+// Status returns the status of every subscription across the connections.
+func (p *Pool) Status() []SubscriptionStatus {
+	p.mu.Lock()
+	connections := append([]*poolConnection(nil), p.connections...)
+	p.mu.Unlock()
+
+	var statuses []SubscriptionStatus
+	for _, connection := range connections {
+		statuses = append(statuses, connection.manager.Status()...)
+	}
+	return statuses
+}
+
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	if p.cancel != nil {
+		p.cancel()
+	}
+	connections := p.connections
+	p.connections = nil
+	p.mu.Unlock()
+
+	var errs []error
+	for _, connection := range connections {
+		err := connection.client.Close()
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("could not close pool connections: %v", errs)
+	}
+	return nil
+}
+
+// open starts a new connection and waits for its subscriptions to be ready.
+func (p *Pool) open() (*poolConnection, error) {
+	p.mu.Lock()
+	if len(p.connections) >= p.MaxConnections {
+		p.mu.Unlock()
+		return nil, ErrPoolFull
+	}
+
+	client := NewClientWithUrl(p.Address)
+	client.handlers = p.handlers
+
+	connection := &poolConnection{
+		client:  client,
+		manager: NewSubscriptionManager(client),
+		ready:   make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	connection.manager.Subscriber = p.Subscriber
+	connection.manager.subscribed = func() {
+		connection.readyOnce.Do(func() { close(connection.ready) })
+	}
+
+	p.connections = append(p.connections, connection)
+	ctx := p.ctx
+	p.mu.Unlock()
+
+	go p.run(ctx, connection)
+
+	select {
+	case <-connection.ready:
+		return connection, nil
+	case <-connection.done:
+		return nil, fmt.Errorf("could not open pool connection to %s", p.Address)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (p *Pool) run(ctx context.Context, connection *poolConnection) {
+	err := connection.client.ConnectWithContext(ctx)
+	close(connection.done)
+	if err != nil && ctx.Err() == nil {
+		p.onError(fmt.Errorf("pool connection dropped: %w", err))
+	}
+
+	p.mu.Lock()
+	for i, c := range p.connections {
+		if c == connection {
+			p.connections = append(p.connections[:i], p.connections[i+1:]...)
+			break
+		}
+	}
+	p.mu.Unlock()
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	// The subscriptions went away with the session, so move them to the other connections
+	for _, status := range connection.manager.Status() {
+		err := p.Subscribe(ctx, status.Request)
+		if err != nil {
+			p.onError(fmt.Errorf("could not move %s subscription to another connection: %w", status.Request.Event, err))
+		}
+	}
+}
+
+func (c *poolConnection) hasRoom(event EventSubscription) bool {
+	usage := c.manager.Usage()
+	if usage.MaxSubscriptions > 0 && usage.Subscriptions+usage.Queued >= usage.MaxSubscriptions {
+		return false
+	}
+	if usage.MaxTotalCost > 0 && usage.TotalCost+expectedCost(event) > usage.MaxTotalCost {
+		return false
+	}
+	return true
+}
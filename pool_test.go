@@ -0,0 +1,86 @@
+package twitch_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPool(t *testing.T, maxTotalCost int) (*twitch.Pool, *fakeHelixSubscriptions) {
+	url, _ := newScriptedServer(t)
+
+	helix := &fakeHelixSubscriptions{maxTotalCost: maxTotalCost}
+	helixServer := httptest.NewServer(helix)
+	t.Cleanup(helixServer.Close)
+
+	pool := twitch.NewPoolWithUrl(url)
+	pool.MaxConnections = 2
+	pool.Subscriber = newTestSubscriptionClient(helixServer.URL)
+	return pool, helix
+}
+
+func poolSubscribed(pool *twitch.Pool, count int) bool {
+	statuses := pool.Status()
+	for _, status := range statuses {
+		if !status.Subscribed {
+			return false
+		}
+	}
+	return len(statuses) == count
+}
+
+func TestPoolOpensConnectionsAsNeeded(t *testing.T) {
+	t.Parallel()
+
+	pool, _ := newTestPool(t, 2)
+
+	var welcomes int32
+	pool.OnWelcome(func(message twitch.WelcomeMessage) {
+		atomic.AddInt32(&welcomes, 1)
+	})
+
+	assert.NoError(t, pool.Subscribe(context.Background(), onlineRequest("1")), "subscribing before connecting is queued")
+
+	go pool.Connect()
+	defer pool.Close()
+
+	assert.Eventually(t, func() bool { return poolSubscribed(pool, 1) }, time.Second, 10*time.Millisecond)
+
+	for _, userID := range []string{"2", "3", "4"} {
+		assert.NoError(t, pool.Subscribe(context.Background(), onlineRequest(userID)))
+	}
+	assert.True(t, poolSubscribed(pool, 4))
+	assert.Len(t, pool.Clients(), 2)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&welcomes), "handlers are shared by every connection")
+
+	err := pool.Subscribe(context.Background(), onlineRequest("5"))
+	assert.ErrorIs(t, err, twitch.ErrPoolFull)
+}
+
+func TestPoolRebalancesDroppedConnection(t *testing.T) {
+	t.Parallel()
+
+	pool, helix := newTestPool(t, 2)
+
+	for _, userID := range []string{"1", "2", "3"} {
+		assert.NoError(t, pool.Subscribe(context.Background(), onlineRequest(userID)))
+	}
+
+	go pool.Connect()
+	defer pool.Close()
+
+	assert.Eventually(t, func() bool { return poolSubscribed(pool, 3) }, time.Second, 10*time.Millisecond)
+	assert.Len(t, pool.Clients(), 2)
+
+	pool.Clients()[0].Close()
+
+	assert.Eventually(t, func() bool {
+		return len(pool.Clients()) == 2 && poolSubscribed(pool, 3)
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 5, helix.postCount(), "the dropped subscriptions are moved to a new connection")
+}
@@ -0,0 +1,208 @@
+package twitch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const redactedValue = "[redacted]"
+
+// DefaultRedactions hides user logins, names, emails and message text.
+var DefaultRedactions = []string{"*_login", "*_user_name", "user_name", "email", "text"}
+
+// RecordedFrame is one frame received by a Client, written as a line of json by a Recorder.
+type RecordedFrame struct {
+	ReceivedAt time.Time       `json:"received_at"`
+	SessionID  string          `json:"session_id"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Recorder writes every frame a Client receives as JSONL.
+type Recorder struct {
+	// Redact lists the json keys whose string values are replaced before writing.
+	// A key starting with * matches every key ending with the rest of it.
+	// It starts as DefaultRedactions, nil writes frames as they were received.
+	Redact []string
+	// Clock is used to name rotated files.
+	Clock Clock
+
+	mu      sync.Mutex
+	w       io.Writer
+	file    *os.File
+	path    string
+	written int64
+	// maxBytes rotates the file once it would grow past it, 0 never rotates.
+	maxBytes int64
+}
+
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{
+		Redact: append([]string(nil), DefaultRedactions...),
+		Clock:  SystemClock,
+		w:      w,
+	}
+}
+
+// NewFileRecorder appends to the file at path. When the file would grow past maxBytes
+// it is renamed with the time as a suffix and a new file is started.
+func NewFileRecorder(path string, maxBytes int64) (*Recorder, error) {
+	recorder := &Recorder{
+		Redact:   append([]string(nil), DefaultRedactions...),
+		Clock:    SystemClock,
+		path:     path,
+		maxBytes: maxBytes,
+	}
+
+	err := recorder.open()
+	if err != nil {
+		return nil, err
+	}
+	return recorder, nil
+}
+
+// Record writes a frame received on the session.
+func (r *Recorder) Record(sessionID string, data []byte, receivedAt time.Time) error {
+	if len(r.Redact) > 0 {
+		var err error
+		data, err = redact(data, r.Redact)
+		if err != nil {
+			return fmt.Errorf("could not redact frame: %w", err)
+		}
+	}
+
+	// Frames that are not json are kept as a json string so the line stays valid
+	if !json.Valid(data) {
+		data, _ = json.Marshal(string(data))
+	}
+
+	line, err := json.Marshal(RecordedFrame{
+		ReceivedAt: receivedAt,
+		SessionID:  sessionID,
+		Data:       data,
+	})
+	if err != nil {
+		return fmt.Errorf("could not marshal recorded frame: %w", err)
+	}
+	line = append(line, '\n')
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file != nil && r.maxBytes > 0 && r.written > 0 && r.written+int64(len(line)) > r.maxBytes {
+		err = r.rotate()
+		if err != nil {
+			return err
+		}
+	}
+
+	n, err := r.w.Write(line)
+	r.written += int64(n)
+	if err != nil {
+		return fmt.Errorf("could not write recorded frame: %w", err)
+	}
+	return nil
+}
+
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Close()
+}
+
+func (r *Recorder) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("could not open recording: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("could not stat recording: %w", err)
+	}
+
+	r.file = file
+	r.w = file
+	r.written = info.Size()
+	return nil
+}
+
+func (r *Recorder) rotate() error {
+	err := r.file.Close()
+	if err != nil {
+		return fmt.Errorf("could not close recording: %w", err)
+	}
+
+	err = os.Rename(r.path, r.rotatedPath())
+	if err != nil {
+		return fmt.Errorf("could not rotate recording: %w", err)
+	}
+	return r.open()
+}
+
+// rotatedPath is the path with the time as a suffix, numbered if a file was already rotated at that time.
+func (r *Recorder) rotatedPath() string {
+	path := fmt.Sprintf("%s.%s", r.path, orSystemClock(r.Clock).Now().UTC().Format("20060102T150405.000000000"))
+	rotated := path
+	for i := 1; ; i++ {
+		if _, err := os.Stat(rotated); errors.Is(err, os.ErrNotExist) {
+			return rotated
+		}
+		rotated = fmt.Sprintf("%s.%d", path, i)
+	}
+}
+
+func redact(data []byte, keys []string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	err := decoder.Decode(&value)
+	if err != nil {
+		// Frames that are not json have nothing to redact
+		return data, nil
+	}
+
+	return json.Marshal(redactValue(value, keys))
+}
+
+func redactValue(value any, keys []string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if _, ok := field.(string); ok && matchesRedaction(key, keys) {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactValue(field, keys)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item, keys)
+		}
+	}
+	return value
+}
+
+func matchesRedaction(key string, keys []string) bool {
+	for _, pattern := range keys {
+		if strings.HasPrefix(pattern, "*") && strings.HasSuffix(key, pattern[1:]) {
+			return true
+		}
+		if key == pattern {
+			return true
+		}
+	}
+	return false
+}
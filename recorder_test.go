@@ -0,0 +1,156 @@
+package twitch_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/joeyak/go-twitch-eventsub/v3/eventsubtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func readRecording(t *testing.T, data []byte) []twitch.RecordedFrame {
+	var frames []twitch.RecordedFrame
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var frame twitch.RecordedFrame
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &frame))
+		frames = append(frames, frame)
+	}
+	return frames
+}
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	server := eventsubtest.NewServer()
+	defer server.Close()
+
+	var recording bytes.Buffer
+	recorder := twitch.NewRecorder(&recording)
+
+	client := twitch.NewClientWithUrl(server.URL)
+	client.SetRecorder(recorder)
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+	notified := make(chan struct{})
+	client.OnEventChannelChatMessage(func(event twitch.EventChannelChatMessage) {
+		close(notified)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		client.Connect()
+		close(done)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	session, err := server.NextSession(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notification, err := eventsubtest.SampleNotification(twitch.SubChannelChatMessage)
+	assert.NoError(t, err)
+	assert.NoError(t, session.Send(notification))
+
+	select {
+	case <-notified:
+	case <-time.After(time.Second):
+		t.Fatal("notification was not received")
+	}
+	client.Close()
+	<-done
+
+	frames := readRecording(t, recording.Bytes())
+	if !assert.Len(t, frames, 2) {
+		return
+	}
+	for _, frame := range frames {
+		assert.Equal(t, session.ID, frame.SessionID)
+		assert.False(t, frame.ReceivedAt.IsZero())
+	}
+	assert.Contains(t, string(frames[0].Data), "session_welcome")
+
+	data := string(frames[1].Data)
+	assert.NotContains(t, data, "viewer32", "logins and names should be redacted")
+	assert.NotContains(t, data, "Hi chat", "message text should be redacted")
+	assert.Contains(t, data, `"chatter_user_id":"4145994"`)
+}
+
+func TestFileRecorderRotates(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "recording.jsonl")
+	recorder, err := twitch.NewFileRecorder(path, 200)
+	assert.NoError(t, err)
+
+	frame := []byte(`{"metadata": {"message_type": "session_keepalive"}, "payload": {}}`)
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, recorder.Record("session", frame, time.Now()))
+	}
+	assert.NoError(t, recorder.Close())
+
+	files, err := filepath.Glob(path + "*")
+	assert.NoError(t, err)
+	assert.Greater(t, len(files), 1)
+
+	total := 0
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(data), 200)
+		total += strings.Count(string(data), "\n")
+	}
+	assert.Equal(t, 5, total, "no frame should be lost while rotating")
+}
+
+func TestRecorderWithoutRedaction(t *testing.T) {
+	t.Parallel()
+
+	var recording bytes.Buffer
+	recorder := twitch.NewRecorder(&recording)
+	recorder.Redact = nil
+
+	assert.NoError(t, recorder.Record("session", []byte(`{"user_name":"viewer32"}`), time.Now()))
+
+	frames := readRecording(t, recording.Bytes())
+	if assert.Len(t, frames, 1) {
+		assert.JSONEq(t, `{"user_name":"viewer32"}`, string(frames[0].Data))
+	}
+}
+
+func TestFileRecorderRotatesWithClock(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := eventsubtest.NewClock(start)
+
+	path := filepath.Join(t.TempDir(), "recording.jsonl")
+	recorder, err := twitch.NewFileRecorder(path, 100)
+	assert.NoError(t, err)
+	recorder.Clock = clock
+
+	frame := []byte(`{"metadata": {"message_type": "session_keepalive"}, "payload": {}}`)
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, recorder.Record("session", frame, clock.Now()))
+	}
+	clock.Advance(time.Minute)
+	assert.NoError(t, recorder.Record("session", frame, clock.Now()))
+	assert.NoError(t, recorder.Close())
+
+	files, err := filepath.Glob(path + ".*")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		path + ".20240501T120000.000000000",
+		path + ".20240501T120000.000000000.1",
+		path + ".20240501T120100.000000000",
+	}, files)
+}
@@ -0,0 +1,171 @@
+package twitch
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// SubscriptionRecord is a subscription known to be active on the client's session.
+type SubscriptionRecord struct {
+	PayloadSubscription
+
+	// Request is the request that created the subscription if it was tracked with one.
+	Request SubscribeRequest
+
+	LastNotificationAt time.Time
+	NotificationCount  int
+}
+
+// RegistrySnapshot is a point in time copy of the registry for diagnostics.
+type RegistrySnapshot struct {
+	SessionID     string
+	TotalCost     int
+	Subscriptions []SubscriptionRecord
+}
+
+// SubscriptionRegistry keeps track of the subscriptions on the current session of a Client.
+// It is cleared on every session_welcome since a new session starts without subscriptions,
+// kept across a session_reconnect, and updated from notifications and revocations.
+type SubscriptionRegistry struct {
+	mu        sync.Mutex
+	sessionID string
+	records   map[string]*SubscriptionRecord
+}
+
+func newSubscriptionRegistry() *SubscriptionRegistry {
+	return &SubscriptionRegistry{
+		records: map[string]*SubscriptionRecord{},
+	}
+}
+
+// Track adds the subscriptions from a subscribe response to the registry.
+func (r *SubscriptionRegistry) Track(request SubscribeRequest, response SubscribeResponse) {
+	for _, subscription := range response.Data {
+		r.track(request, subscription)
+	}
+}
+
+func (r *SubscriptionRegistry) track(request SubscribeRequest, subscription PayloadSubscription) {
+	if subscription.ID == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.records[subscription.ID]
+	if !ok {
+		record = &SubscriptionRecord{}
+		r.records[subscription.ID] = record
+	}
+	record.PayloadSubscription = subscription
+	record.Request = request
+}
+
+// Get returns the subscription with the id.
+func (r *SubscriptionRegistry) Get(id string) (SubscriptionRecord, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.records[id]
+	if !ok {
+		return SubscriptionRecord{}, false
+	}
+	return *record, true
+}
+
+// ByType returns the subscriptions of an event type.
+func (r *SubscriptionRegistry) ByType(event EventSubscription) []SubscriptionRecord {
+	var records []SubscriptionRecord
+	for _, record := range r.Snapshot().Subscriptions {
+		if record.Type == event {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// Len returns how many subscriptions are registered.
+func (r *SubscriptionRegistry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.records)
+}
+
+// Snapshot returns a copy of the registry sorted by creation time.
+func (r *SubscriptionRegistry) Snapshot() RegistrySnapshot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snapshot := RegistrySnapshot{
+		SessionID:     r.sessionID,
+		Subscriptions: make([]SubscriptionRecord, 0, len(r.records)),
+	}
+	for _, record := range r.records {
+		snapshot.TotalCost += record.Cost
+		snapshot.Subscriptions = append(snapshot.Subscriptions, *record)
+	}
+
+	sort.Slice(snapshot.Subscriptions, func(i, j int) bool {
+		a, b := snapshot.Subscriptions[i], snapshot.Subscriptions[j]
+		if !a.CreateAt.Equal(b.CreateAt) {
+			return a.CreateAt.Before(b.CreateAt)
+		}
+		return a.ID < b.ID
+	})
+
+	return snapshot
+}
+
+func (r *SubscriptionRegistry) currentSessionID() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sessionID
+}
+
+// reset clears the registry for a new session.
+func (r *SubscriptionRegistry) reset(sessionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessionID = sessionID
+	r.records = map[string]*SubscriptionRecord{}
+}
+
+// migrate keeps the subscriptions when twitch moves them to a new connection.
+func (r *SubscriptionRegistry) migrate(sessionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessionID = sessionID
+	for _, record := range r.records {
+		if record.Transport.Method == string(TransportWebsocket) {
+			record.Transport.SessionID = sessionID
+		}
+	}
+}
+
+func (r *SubscriptionRegistry) notified(subscription PayloadSubscription, at time.Time) {
+	if subscription.ID == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.records[subscription.ID]
+	if !ok {
+		record = &SubscriptionRecord{}
+		r.records[subscription.ID] = record
+	}
+	record.PayloadSubscription = subscription
+	record.LastNotificationAt = at
+	record.NotificationCount++
+}
+
+func (r *SubscriptionRegistry) remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.records, id)
+}
@@ -0,0 +1,75 @@
+package twitch_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryTrack(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient()
+	registry := client.Registry()
+
+	now := time.Now()
+	request := onlineRequest("1")
+	registry.Track(request, twitch.SubscribeResponse{
+		Data: []twitch.PayloadSubscription{
+			{ID: "b", Cost: 1, CreateAt: now.Add(time.Second), SubscriptionRequest: twitch.SubscriptionRequest{Type: twitch.SubStreamOnline}},
+			{ID: "a", Cost: 0, CreateAt: now, SubscriptionRequest: twitch.SubscriptionRequest{Type: twitch.SubStreamOffline}},
+		},
+	})
+
+	assert.Equal(t, 2, registry.Len())
+
+	record, ok := registry.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, request.Event, record.Request.Event)
+
+	assert.Len(t, registry.ByType(twitch.SubStreamOffline), 1)
+
+	snapshot := registry.Snapshot()
+	assert.Equal(t, 1, snapshot.TotalCost)
+	assert.Equal(t, "a", snapshot.Subscriptions[0].ID)
+	assert.Equal(t, "b", snapshot.Subscriptions[1].ID)
+}
+
+func TestRegistryFollowsSession(t *testing.T) {
+	t.Parallel()
+
+	url, frames := newScriptedServer(t)
+	client := twitch.NewClientWithUrl(url)
+	client.OnError(func(err error) { t.Errorf("client registered an error: %v", err) })
+
+	manager, helix := newTestSubscriptionManager(t, client)
+	manager.RevocationPolicy = func(twitch.SubscriptionStatus, twitch.RevocationReason) twitch.RevocationAction {
+		return twitch.RevocationDrop
+	}
+	assert.NoError(t, manager.Add(context.Background(), onlineRequest("1")))
+
+	go connect(t, client)
+	defer client.Close()
+
+	registry := client.Registry()
+	assert.Eventually(t, func() bool { return registry.Len() == 1 }, time.Second, 10*time.Millisecond)
+
+	snapshot := registry.Snapshot()
+	assert.Equal(t, manager.SessionID(), snapshot.SessionID)
+
+	subscription := snapshot.Subscriptions[0].PayloadSubscription
+	assert.Equal(t, twitch.SubStreamOnline, snapshot.Subscriptions[0].Request.Event)
+
+	frames <- newNotification(subscription, `{}`)
+	assert.Eventually(t, func() bool {
+		record, _ := registry.Get(subscription.ID)
+		return record.NotificationCount == 1
+	}, time.Second, 10*time.Millisecond)
+
+	helix.revoke(subscription.ID)
+	frames <- newRevocation(subscription, twitch.RevocationUserRemoved)
+	assert.Eventually(t, func() bool { return registry.Len() == 0 }, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,103 @@
+package twitch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const maxReplayLineSize = 16 << 20
+
+// FrameSource supplies frames to a Client in place of the websocket.
+// Next returns io.EOF when there are no frames left.
+type FrameSource interface {
+	Next(ctx context.Context) ([]byte, error)
+}
+
+// ReplaySource replays frames written by a Recorder.
+type ReplaySource struct {
+	// Speed replays at the original timing multiplied by it, 2 is twice as fast.
+	// Frames are replayed as fast as possible when it is 0.
+	Speed float64
+	// Clock is used to wait between frames.
+	Clock Clock
+
+	scanner *bufio.Scanner
+	last    time.Time
+}
+
+func NewReplaySource(r io.Reader) *ReplaySource {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxReplayLineSize)
+	return &ReplaySource{scanner: scanner, Clock: SystemClock}
+}
+
+func (s *ReplaySource) Next(ctx context.Context) ([]byte, error) {
+	for s.scanner.Scan() {
+		if len(s.scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var frame RecordedFrame
+		err := json.Unmarshal(s.scanner.Bytes(), &frame)
+		if err != nil {
+			return nil, fmt.Errorf("could not unmarshal recorded frame: %w", err)
+		}
+
+		if s.Speed > 0 && !s.last.IsZero() {
+			err = sleepContext(ctx, s.Clock, time.Duration(float64(frame.ReceivedAt.Sub(s.last))/s.Speed))
+			if err != nil {
+				return nil, err
+			}
+		}
+		s.last = frame.ReceivedAt
+
+		// Frames that were not json were recorded as a json string
+		var text string
+		if json.Unmarshal(frame.Data, &text) == nil {
+			return []byte(text), nil
+		}
+		return frame.Data, nil
+	}
+
+	if err := s.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read recording: %w", err)
+	}
+	return nil, io.EOF
+}
+
+// SetFrameSource makes the client read frames from the source instead of connecting to twitch.
+// A session_reconnect does not dial, the welcome that follows it is taken as the new session.
+// An attached SubscriptionManager or ConduitMode does not send requests for replayed sessions.
+func (c *Client) SetFrameSource(source FrameSource) {
+	c.source = source
+}
+
+func (c *Client) replay(ctx context.Context) error {
+	ctx, c.cancelReplay = context.WithCancel(ctx)
+	defer c.cancelReplay()
+
+	c.ctx = ctx
+	c.connected = true
+
+	for {
+		data, err := c.source.Next(ctx)
+		if errors.Is(err, io.EOF) || errors.Is(err, context.Canceled) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not read replayed frame: %w", err)
+		}
+
+		receivedAt := c.clock.Now()
+		err = c.handleMessage(data)
+		if err != nil {
+			c.onError(err)
+		}
+		c.record(data, receivedAt)
+	}
+}
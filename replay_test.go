@@ -0,0 +1,166 @@
+package twitch_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/joeyak/go-twitch-eventsub/v3/eventsubtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newWelcomeFrame(t *testing.T, sessionID string) []byte {
+	return newWelcomeFrameData(t, sessionID)
+}
+
+func newWelcomeFrameData(t testing.TB, sessionID string) []byte {
+	var welcome twitch.WelcomeMessage
+	welcome.Metadata = newMetadata("session_welcome")
+	welcome.Payload.Session = twitch.PayloadSession{ID: sessionID, Status: "connected"}
+
+	data, err := json.Marshal(welcome)
+	assert.NoError(t, err)
+	return data
+}
+
+func newReconnectFrame(t *testing.T, sessionID string) []byte {
+	var reconnect twitch.ReconnectMessage
+	reconnect.Metadata = newMetadata("session_reconnect")
+	reconnect.Payload.Session = twitch.PayloadSession{ID: sessionID, Status: "reconnecting", ReconnectUrl: "wss://unreachable.invalid/ws"}
+
+	data, err := json.Marshal(reconnect)
+	assert.NoError(t, err)
+	return data
+}
+
+func TestReplaySource(t *testing.T) {
+	t.Parallel()
+
+	notification, err := eventsubtest.SampleNotification(twitch.SubChannelChatMessage)
+	assert.NoError(t, err)
+
+	var recording bytes.Buffer
+	recorder := twitch.NewRecorder(&recording)
+	start := time.Now()
+	for i, frame := range [][]byte{
+		newWelcomeFrame(t, "first"),
+		notification,
+		newReconnectFrame(t, "first"),
+		newWelcomeFrame(t, "second"),
+		notification,
+	} {
+		assert.NoError(t, recorder.Record("", frame, start.Add(time.Duration(i)*20*time.Millisecond)))
+	}
+
+	client := twitch.NewClient()
+	source := twitch.NewReplaySource(&recording)
+	source.Speed = 2
+	client.SetFrameSource(source)
+
+	var welcomes, messages int32
+	client.OnWelcome(func(message twitch.WelcomeMessage) {
+		atomic.AddInt32(&welcomes, 1)
+	})
+	client.OnEventChannelChatMessage(func(event twitch.EventChannelChatMessage) {
+		atomic.AddInt32(&messages, 1)
+	})
+
+	assert.NoError(t, client.Connect())
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond, "frames should keep their timing at twice the speed")
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&messages) == 2 }, time.Second, time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&welcomes), "the welcome after a reconnect is not a new session")
+	assert.Equal(t, "second", client.Registry().Snapshot().SessionID)
+}
+
+func TestReplayWithoutRequests(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	helixServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer helixServer.Close()
+
+	subscription := twitch.PayloadSubscription{
+		SubscriptionRequest: twitch.SubscriptionRequest{Type: twitch.SubStreamOnline, Version: "1"},
+		ID:                  "sub-1",
+	}
+
+	// The last frame comes later so requests would be made while replaying
+	var recording bytes.Buffer
+	recorder := twitch.NewRecorder(&recording)
+	start := time.Now()
+	for i, frame := range [][]byte{
+		newWelcomeFrame(t, "first"),
+		newRevocation(subscription, "authorization_revoked"),
+		newReconnectFrame(t, "first"),
+		newWelcomeFrame(t, "second"),
+	} {
+		assert.NoError(t, recorder.Record("", frame, start.Add(time.Duration(i)*50*time.Millisecond)))
+	}
+
+	client := twitch.NewClient()
+	source := twitch.NewReplaySource(&recording)
+	source.Speed = 1
+	client.SetFrameSource(source)
+	client.OnError(func(err error) {})
+
+	manager := twitch.NewSubscriptionManager(client)
+	manager.Subscriber = newTestSubscriptionClient(helixServer.URL)
+	assert.NoError(t, manager.Add(context.Background(), onlineRequest("1")))
+
+	conduit := twitch.NewConduitMode(client, "conduit", "0")
+	conduit.Subscriber = twitch.NewSubscriptionClientWithUrl(helixServer.URL)
+	conduit.Subscriber.ConduitUrl = helixServer.URL
+
+	assert.NoError(t, client.Connect())
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requests), "replaying sends no helix requests")
+}
+
+func TestReplaySourceFromRecorder(t *testing.T) {
+	t.Parallel()
+
+	server := eventsubtest.NewServer()
+	defer server.Close()
+
+	var recording bytes.Buffer
+	live := twitch.NewClientWithUrl(server.URL)
+	live.SetRecorder(twitch.NewRecorder(&recording))
+	live.OnWelcome(func(message twitch.WelcomeMessage) {})
+	live.OnKeepAlive(func(message twitch.KeepAliveMessage) {
+		live.Close()
+	})
+	server.Scenario = []eventsubtest.Step{eventsubtest.MalformedJSON(), eventsubtest.KeepAlive()}
+	live.OnError(func(err error) {})
+	assert.NoError(t, live.Connect())
+
+	replayed := twitch.NewClient()
+	replayed.SetFrameSource(twitch.NewReplaySource(&recording))
+	replayed.OnWelcome(func(message twitch.WelcomeMessage) {})
+
+	errs := make(chan error, 1)
+	replayed.OnError(func(err error) {
+		errs <- err
+	})
+	keepalives := make(chan struct{}, 1)
+	replayed.OnKeepAlive(func(message twitch.KeepAliveMessage) {
+		keepalives <- struct{}{}
+	})
+
+	assert.NoError(t, replayed.Connect())
+	assert.ErrorContains(t, <-errs, "could not unmarshal basemessage", "frames that are not json are replayed as they were received")
+
+	select {
+	case <-keepalives:
+	case <-time.After(time.Second):
+		t.Fatal("keepalive was not replayed")
+	}
+}
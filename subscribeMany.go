@@ -0,0 +1,340 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const maxWebsocketSubscriptions = 300
+
+var (
+	ErrMaxTotalCostExceeded     = fmt.Errorf("subscription would exceed the max total cost")
+	ErrMaxSubscriptionsExceeded = fmt.Errorf("subscription would exceed the max subscriptions")
+)
+
+// BudgetExceededError is returned when a subscription would go over the max total cost
+// or the max number of subscriptions on a connection.
+type BudgetExceededError struct {
+	Event EventSubscription
+	Cost  int
+
+	TotalCost    int
+	MaxTotalCost int
+
+	Subscriptions    int
+	MaxSubscriptions int
+}
+
+func (e *BudgetExceededError) Error() string {
+	if e.overSubscriptions() {
+		return fmt.Sprintf("subscribing to %s would exceed the max of %d subscriptions", e.Event, e.MaxSubscriptions)
+	}
+	return fmt.Sprintf("subscribing to %s would exceed the max total cost: %d + %d > %d", e.Event, e.TotalCost, e.Cost, e.MaxTotalCost)
+}
+
+func (e *BudgetExceededError) Is(target error) bool {
+	if e.overSubscriptions() {
+		return target == ErrMaxSubscriptionsExceeded
+	}
+	return target == ErrMaxTotalCostExceeded
+}
+
+func (e *BudgetExceededError) overSubscriptions() bool {
+	return e.MaxSubscriptions > 0 && e.Subscriptions+1 > e.MaxSubscriptions
+}
+
+type SubscribeResult struct {
+	Request      SubscribeRequest
+	Subscription PayloadSubscription
+
+	// Existing is true when twitch responded with a conflict because the subscription already existed.
+	Existing bool
+	Err      error
+}
+
+// SubscribeManyError lists the requests that failed in SubscribeMany.
+type SubscribeManyError struct {
+	Failed []SubscribeResult
+}
+
+func (e *SubscribeManyError) Error() string {
+	messages := make([]string, len(e.Failed))
+	for i, result := range e.Failed {
+		messages[i] = fmt.Sprintf("%s: %v", result.Request.Event, result.Err)
+	}
+	return fmt.Sprintf("could not subscribe to %d events: %s", len(e.Failed), strings.Join(messages, "; "))
+}
+
+// Is reports whether the error of any failed request matches target.
+func (e *SubscribeManyError) Is(target error) bool {
+	for _, result := range e.Failed {
+		if errors.Is(result.Err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error of a failed request that matches target.
+func (e *SubscribeManyError) As(target any) bool {
+	for _, result := range e.Failed {
+		if errors.As(result.Err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func SubscribeMany(ctx context.Context, sessionID string, requests []SubscribeRequest) ([]SubscribeResult, error) {
+	return sharedSubscriptionClient(twitchEventSubUrl).SubscribeMany(ctx, sessionID, requests)
+}
+
+// SubscribeMany subscribes every request to the session using up to MaxConcurrency requests at once.
+// A result is returned for every request in the same order, and the error is a *SubscribeManyError
+// if any of them failed. Once the max total cost would be exceeded the remaining requests
+// are not sent and fail with a *BudgetExceededError.
+func (c *SubscriptionClient) SubscribeMany(ctx context.Context, sessionID string, requests []SubscribeRequest) ([]SubscribeResult, error) {
+	return c.subscribeMany(ctx, sessionID, requests, &costBudget{})
+}
+
+func (c *SubscriptionClient) subscribeMany(ctx context.Context, sessionID string, requests []SubscribeRequest, budget *costBudget) ([]SubscribeResult, error) {
+	results := make([]SubscribeResult, len(requests))
+	batch := &budgetBatch{budget: budget}
+
+	concurrency := c.MaxConcurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				request := requests[i]
+				request.SessionID = sessionID
+				results[i] = c.subscribeWithBudget(ctx, request, batch)
+			}
+		}()
+	}
+
+	for i := range requests {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var failed []SubscribeResult
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	if len(failed) > 0 {
+		return results, &SubscribeManyError{Failed: failed}
+	}
+	return results, nil
+}
+
+func (c *SubscriptionClient) subscribeWithBudget(ctx context.Context, request SubscribeRequest, batch *budgetBatch) SubscribeResult {
+	result := SubscribeResult{Request: request}
+
+	cost := expectedCost(request.Event)
+	err := batch.reserve(request.Event, cost)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	resp, err := c.Subscribe(ctx, request)
+	batch.budget.release(cost, resp, err)
+
+	var respErr *ResponseError
+	if errors.As(err, &respErr) && respErr.StatusCode == http.StatusConflict {
+		result.Existing = true
+		result.Subscription, err = c.findSubscription(ctx, request)
+	} else if err == nil && len(resp.Data) > 0 {
+		result.Subscription = resp.Data[0]
+	}
+
+	result.Err = err
+	return result
+}
+
+// findSubscription looks up the existing subscription that matches the request.
+func (c *SubscriptionClient) findSubscription(ctx context.Context, request SubscribeRequest) (PayloadSubscription, error) {
+	condition, err := request.condition()
+	if err != nil {
+		return PayloadSubscription{}, err
+	}
+
+	filter := SubscriptionFilter{Type: request.Event}
+	for {
+		resp, err := c.GetSubscriptions(ctx, request.ClientID, request.AccessToken, filter)
+		if err != nil {
+			return PayloadSubscription{}, fmt.Errorf("could not look up existing subscription: %w", err)
+		}
+
+		for _, subscription := range resp.Data {
+			if request.matchesTransport(subscription.Transport) && conditionsEqual(subscription.Condition, condition) {
+				return subscription, nil
+			}
+		}
+
+		if resp.Pagination.Cursor == "" {
+			return PayloadSubscription{}, fmt.Errorf("could not find existing %s subscription", request.Event)
+		}
+		filter.After = resp.Pagination.Cursor
+	}
+}
+
+// conditionsEqual compares conditions ignoring empty values, which twitch may leave in or out.
+func conditionsEqual(a, b map[string]string) bool {
+	for key, value := range a {
+		if value != "" && b[key] != value {
+			return false
+		}
+	}
+	for key, value := range b {
+		if value != "" && a[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// expectedCost is the most a subscription to the event can cost.
+func expectedCost(event EventSubscription) int {
+	if metadata, ok := subMetadata[event]; ok && metadata.Cost == CostAuthorized {
+		return 0
+	}
+	return 1
+}
+
+// costBudget tracks the total cost and subscription count reported by twitch along with the requests in flight.
+// Until a response has reported the max total cost only one request is let through at a time.
+type costBudget struct {
+	mu           sync.Mutex
+	cond         *sync.Cond
+	total        int
+	max          int
+	pending      int
+	count        int
+	maxCount     int
+	pendingCount int
+}
+
+func (b *costBudget) reserve(event EventSubscription, cost int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.cond == nil {
+		b.cond = sync.NewCond(&b.mu)
+	}
+	for b.max == 0 && b.pendingCount > 0 {
+		b.cond.Wait()
+	}
+
+	overCount := b.maxCount > 0 && b.count+b.pendingCount+1 > b.maxCount
+	overCost := b.max > 0 && b.total+b.pending+cost > b.max
+	if overCount || overCost {
+		return &BudgetExceededError{
+			Event:            event,
+			Cost:             cost,
+			TotalCost:        b.total + b.pending,
+			MaxTotalCost:     b.max,
+			Subscriptions:    b.count + b.pendingCount,
+			MaxSubscriptions: b.maxCount,
+		}
+	}
+
+	b.pending += cost
+	b.pendingCount++
+	return nil
+}
+
+func (b *costBudget) release(cost int, resp SubscribeResponse, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.pending -= cost
+	b.pendingCount--
+	if b.cond != nil {
+		b.cond.Broadcast()
+	}
+
+	var respErr *ResponseError
+	if err == nil || (errors.As(err, &respErr) && respErr.StatusCode == http.StatusConflict) {
+		b.count++
+	}
+
+	if err == nil && resp.MaxTotalCost > 0 {
+		// Responses can arrive out of order, so keep the highest total seen
+		if resp.TotalCost > b.total {
+			b.total = resp.TotalCost
+		}
+		b.max = resp.MaxTotalCost
+	}
+}
+
+// remove gives back the cost of a subscription that no longer exists.
+func (b *costBudget) remove(cost int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.total -= cost
+	if b.total < 0 {
+		b.total = 0
+	}
+	if b.count > 0 {
+		b.count--
+	}
+}
+
+// reset starts over for a new session, keeping the known limits.
+func (b *costBudget) reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.total = 0
+	b.count = 0
+}
+
+func (b *costBudget) usage() (total, max, count, maxCount int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.total, b.max, b.count, b.maxCount
+}
+
+// budgetBatch stops a batch of subscriptions once one of them would exceed the budget.
+type budgetBatch struct {
+	budget *costBudget
+
+	mu       sync.Mutex
+	exceeded *BudgetExceededError
+}
+
+func (b *budgetBatch) reserve(event EventSubscription, cost int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.exceeded != nil {
+		exceeded := *b.exceeded
+		exceeded.Event = event
+		exceeded.Cost = cost
+		return &exceeded
+	}
+
+	err := b.budget.reserve(event, cost)
+	if err != nil {
+		b.exceeded = err.(*BudgetExceededError)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package twitch_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func onlineRequest(userID string) twitch.SubscribeRequest {
+	return twitch.SubscribeRequest{
+		Event:          twitch.SubStreamOnline,
+		TypedCondition: twitch.BroadcasterCondition{BroadcasterUserID: userID},
+	}
+}
+
+func TestSubscribeMany(t *testing.T) {
+	t.Parallel()
+
+	helix := &fakeHelixSubscriptions{maxTotalCost: 10}
+	helix.subscriptions = append(helix.subscriptions, twitch.PayloadSubscription{
+		SubscriptionRequest: twitch.SubscriptionRequest{
+			Type:      twitch.SubStreamOnline,
+			Condition: map[string]string{"broadcaster_user_id": "2"},
+			Transport: twitch.SubscriptionTransport{Method: "websocket", SessionID: "session"},
+		},
+		ID:   "existing",
+		Cost: 1,
+	})
+	server := httptest.NewServer(helix)
+	defer server.Close()
+
+	results, err := newTestSubscriptionClient(server.URL).SubscribeMany(context.Background(), "session", []twitch.SubscribeRequest{
+		onlineRequest("1"),
+		onlineRequest("2"),
+		{Event: twitch.SubStreamOffline},
+		onlineRequest("3"),
+	})
+
+	var manyErr *twitch.SubscribeManyError
+	if assert.ErrorAs(t, err, &manyErr) {
+		assert.Len(t, manyErr.Failed, 1)
+		assert.Equal(t, twitch.SubStreamOffline, manyErr.Failed[0].Request.Event)
+	}
+
+	assert.Len(t, results, 4)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "session", results[0].Subscription.Transport.SessionID)
+
+	assert.NoError(t, results[1].Err)
+	assert.True(t, results[1].Existing)
+	assert.Equal(t, "existing", results[1].Subscription.ID)
+
+	var validationErr *twitch.ValidationError
+	assert.ErrorAs(t, results[2].Err, &validationErr)
+
+	assert.NoError(t, results[3].Err)
+}
+
+func TestSubscribeManyStopsAtMaxTotalCost(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(&fakeHelixSubscriptions{maxTotalCost: 2})
+	defer server.Close()
+
+	client := newTestSubscriptionClient(server.URL)
+	client.MaxConcurrency = 1
+
+	results, err := client.SubscribeMany(context.Background(), "session", []twitch.SubscribeRequest{
+		onlineRequest("1"),
+		onlineRequest("2"),
+		onlineRequest("3"),
+		onlineRequest("4"),
+	})
+	assert.ErrorIs(t, err, twitch.ErrMaxTotalCostExceeded)
+	assert.NotErrorIs(t, err, twitch.ErrMaxSubscriptionsExceeded)
+
+	var budgetErr *twitch.BudgetExceededError
+	if assert.ErrorAs(t, err, &budgetErr) {
+		assert.Equal(t, 2, budgetErr.MaxTotalCost)
+	}
+
+	assert.NoError(t, results[0].Err)
+	assert.NoError(t, results[1].Err)
+	assert.ErrorIs(t, results[2].Err, twitch.ErrMaxTotalCostExceeded)
+	assert.ErrorIs(t, results[3].Err, twitch.ErrMaxTotalCostExceeded)
+}
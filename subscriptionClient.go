@@ -0,0 +1,347 @@
+package twitch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxRetries     = 3
+	defaultMaxConcurrency = 4
+	defaultMinBackoff     = 500 * time.Millisecond
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+// RateLimit is the helix rate limit state from the last response.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// ResponseError is returned when helix responds with an unexpected status.
+type ResponseError struct {
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Status, e.Body)
+}
+
+// SubscriptionClient sends subscription requests to helix.
+// It paces requests using the rate limit headers and retries
+// rate limited and server error responses with backoff.
+type SubscriptionClient struct {
+	Url         string
+	ConduitUrl  string
+	ValidateUrl string
+	HTTPClient  *http.Client
+
+	// MaxRetries is how many times a request is retried after a 429 or 5xx response.
+	MaxRetries int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// MaxConcurrency is how many requests SubscribeMany sends at once.
+	MaxConcurrency int
+
+	// Clock is used for backoff and rate limit waits.
+	Clock Clock
+
+	mu        sync.Mutex
+	rateLimit RateLimit
+}
+
+func NewSubscriptionClient() *SubscriptionClient {
+	return NewSubscriptionClientWithUrl(twitchEventSubUrl)
+}
+
+func NewSubscriptionClientWithUrl(url string) *SubscriptionClient {
+	return &SubscriptionClient{
+		Url:         url,
+		ConduitUrl:  twitchConduitUrl,
+		ValidateUrl: twitchValidateUrl,
+		MaxRetries:  defaultMaxRetries,
+		MinBackoff:  defaultMinBackoff,
+		MaxBackoff:  defaultMaxBackoff,
+
+		MaxConcurrency: defaultMaxConcurrency,
+		Clock:          SystemClock,
+	}
+}
+
+var (
+	sharedClientsMu sync.Mutex
+	sharedClients   = map[string]*SubscriptionClient{}
+)
+
+// sharedSubscriptionClient returns the client the package level functions use for the url,
+// so their requests share the pacing and rate limit state.
+func sharedSubscriptionClient(url string) *SubscriptionClient {
+	sharedClientsMu.Lock()
+	defer sharedClientsMu.Unlock()
+
+	client, ok := sharedClients[url]
+	if !ok {
+		client = NewSubscriptionClientWithUrl(url)
+		sharedClients[url] = client
+	}
+	return client
+}
+
+// withOwnRateLimit returns a client with the settings of c that paces its requests on its own.
+// Helix rate limits are per token, so clients used with different tokens should not share the state.
+func (c *SubscriptionClient) withOwnRateLimit() *SubscriptionClient {
+	return &SubscriptionClient{
+		Url:         c.Url,
+		ConduitUrl:  c.ConduitUrl,
+		ValidateUrl: c.ValidateUrl,
+		HTTPClient:  c.HTTPClient,
+		MaxRetries:  c.MaxRetries,
+		MinBackoff:  c.MinBackoff,
+		MaxBackoff:  c.MaxBackoff,
+
+		MaxConcurrency: c.MaxConcurrency,
+		Clock:          c.Clock,
+	}
+}
+
+// RateLimit returns the rate limit reported by the last helix response.
+func (c *SubscriptionClient) RateLimit() RateLimit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rateLimit
+}
+
+func (c *SubscriptionClient) Subscribe(ctx context.Context, request SubscribeRequest) (SubscribeResponse, error) {
+	err := request.Validate()
+	if err != nil {
+		return SubscribeResponse{}, err
+	}
+
+	condition, err := request.condition()
+	if err != nil {
+		return SubscribeResponse{}, err
+	}
+
+	version := subMetadata[request.Event].Version
+	if request.VersionOverride != "" {
+		version = request.VersionOverride
+	}
+
+	b, err := json.Marshal(SubscriptionRequest{
+		Type:      request.Event,
+		Version:   version,
+		Condition: condition,
+		Transport: request.subscriptionTransport(),
+	})
+	if err != nil {
+		return SubscribeResponse{}, fmt.Errorf("could not convert request to json: %w", err)
+	}
+
+	var subscription SubscribeResponse
+	err = c.do(ctx, http.MethodPost, c.Url, request.ClientID, request.AccessToken, b, http.StatusAccepted, &subscription)
+	if err != nil {
+		return SubscribeResponse{}, fmt.Errorf("could not subscribe to event: %w", err)
+	}
+
+	return subscription, nil
+}
+
+type SubscriptionFilter struct {
+	Status         string
+	Type           EventSubscription
+	UserID         string
+	SubscriptionID string
+	After          string
+}
+
+type Pagination struct {
+	Cursor string `json:"cursor"`
+}
+
+type GetSubscriptionsResponse struct {
+	SubscribeResponse
+	Pagination Pagination `json:"pagination"`
+}
+
+// GetSubscriptions lists one page of the subscriptions visible to the token.
+// Only one of Status, Type, UserID and SubscriptionID can be used at a time.
+func (c *SubscriptionClient) GetSubscriptions(ctx context.Context, clientID, accessToken string, filter SubscriptionFilter) (GetSubscriptionsResponse, error) {
+	query := url.Values{}
+	for key, value := range map[string]string{
+		"status":          filter.Status,
+		"type":            string(filter.Type),
+		"user_id":         filter.UserID,
+		"subscription_id": filter.SubscriptionID,
+		"after":           filter.After,
+	} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	requestUrl := c.Url
+	if len(query) > 0 {
+		requestUrl = fmt.Sprintf("%s?%s", c.Url, query.Encode())
+	}
+
+	var subscriptions GetSubscriptionsResponse
+	err := c.do(ctx, http.MethodGet, requestUrl, clientID, accessToken, nil, http.StatusOK, &subscriptions)
+	if err != nil {
+		return GetSubscriptionsResponse{}, fmt.Errorf("could not get subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (c *SubscriptionClient) DeleteSubscription(ctx context.Context, clientID, accessToken, id string) error {
+	requestUrl := fmt.Sprintf("%s?%s", c.Url, url.Values{"id": {id}}.Encode())
+
+	err := c.do(ctx, http.MethodDelete, requestUrl, clientID, accessToken, nil, http.StatusNoContent, nil)
+	if err != nil {
+		return fmt.Errorf("could not delete subscription: %w", err)
+	}
+	return nil
+}
+
+func (c *SubscriptionClient) do(ctx context.Context, method, requestUrl, clientID, accessToken string, body []byte, expectedStatus int, response any) error {
+	for attempt := 0; ; attempt++ {
+		err := c.waitForRateLimit(ctx)
+		if err != nil {
+			return err
+		}
+
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, requestUrl, reader)
+		if err != nil {
+			return fmt.Errorf("could not create new request: %w", err)
+		}
+
+		req.Header.Set("Client-Id", clientID)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := c.httpClient().Do(req)
+		if err != nil {
+			return err
+		}
+
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		c.updateRateLimit(resp.Header)
+
+		if resp.StatusCode == expectedStatus {
+			if response == nil {
+				return nil
+			}
+
+			err = json.Unmarshal(data, response)
+			if err != nil {
+				return fmt.Errorf("could not unmarshal response: %w", err)
+			}
+			return nil
+		}
+
+		respErr := &ResponseError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Body:       string(data),
+		}
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		if !retryable || attempt >= c.MaxRetries {
+			return respErr
+		}
+
+		err = sleepContext(ctx, c.Clock, c.backoff(attempt, resp.StatusCode))
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (c *SubscriptionClient) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (c *SubscriptionClient) updateRateLimit(header http.Header) {
+	limit, limitErr := strconv.Atoi(header.Get("Ratelimit-Limit"))
+	remaining, remainingErr := strconv.Atoi(header.Get("Ratelimit-Remaining"))
+	reset, resetErr := strconv.ParseInt(header.Get("Ratelimit-Reset"), 10, 64)
+	if limitErr != nil || remainingErr != nil || resetErr != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rateLimit = RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     time.Unix(reset, 0),
+	}
+}
+
+// waitForRateLimit blocks until the bucket refills if the last response said it was empty.
+func (c *SubscriptionClient) waitForRateLimit(ctx context.Context) error {
+	c.mu.Lock()
+	rateLimit := c.rateLimit
+	c.mu.Unlock()
+
+	if rateLimit.Limit == 0 || rateLimit.Remaining > 0 {
+		return nil
+	}
+	return sleepContext(ctx, c.Clock, rateLimit.Reset.Sub(orSystemClock(c.Clock).Now()))
+}
+
+func (c *SubscriptionClient) backoff(attempt, statusCode int) time.Duration {
+	if statusCode == http.StatusTooManyRequests {
+		c.mu.Lock()
+		reset := c.rateLimit.Reset
+		c.mu.Unlock()
+
+		if wait := reset.Sub(orSystemClock(c.Clock).Now()); wait > 0 {
+			return wait
+		}
+	}
+
+	backoff := c.MinBackoff << attempt
+	if c.MaxBackoff > 0 && (backoff > c.MaxBackoff || backoff <= 0) {
+		backoff = c.MaxBackoff
+	}
+	return backoff
+}
+
+func sleepContext(ctx context.Context, clock Clock, duration time.Duration) error {
+	if duration <= 0 {
+		return nil
+	}
+
+	timer := orSystemClock(clock).NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C():
+		return nil
+	}
+}
@@ -0,0 +1,155 @@
+package twitch_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSubscriptionClient(url string) *twitch.SubscriptionClient {
+	client := twitch.NewSubscriptionClientWithUrl(url)
+	client.MinBackoff = time.Millisecond
+	client.MaxBackoff = 10 * time.Millisecond
+	return client
+}
+
+func validSubscribeRequest() twitch.SubscribeRequest {
+	return twitch.SubscribeRequest{
+		SessionID:      "session",
+		Event:          twitch.SubStreamOnline,
+		TypedCondition: twitch.BroadcasterCondition{BroadcasterUserID: "12345"},
+	}
+}
+
+func TestSubscribeRetriesRateLimit(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Ratelimit-Limit", "800")
+		w.Header().Set("Ratelimit-Reset", strconv.FormatInt(time.Now().Unix(), 10))
+
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			w.Header().Set("Ratelimit-Remaining", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.Header().Set("Ratelimit-Remaining", "799")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"data":[{"id":"abc"}],"total":1,"total_cost":1,"max_total_cost":10}`)
+	}))
+	defer server.Close()
+
+	client := newTestSubscriptionClient(server.URL)
+	resp, err := client.Subscribe(context.Background(), validSubscribeRequest())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, 1, resp.TotalCost)
+	assert.Equal(t, 800, client.RateLimit().Limit)
+	assert.Equal(t, 799, client.RateLimit().Remaining)
+}
+
+func TestSubscribeRetryBudget(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := newTestSubscriptionClient(server.URL)
+	client.MaxRetries = 2
+
+	_, err := client.Subscribe(context.Background(), validSubscribeRequest())
+
+	var respErr *twitch.ResponseError
+	if assert.ErrorAs(t, err, &respErr) {
+		assert.Equal(t, http.StatusServiceUnavailable, respErr.StatusCode)
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestSubscribeDoesNotRetryClientErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	_, err := newTestSubscriptionClient(server.URL).Subscribe(context.Background(), validSubscribeRequest())
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestSubscribeWaitsForRateLimitReset(t *testing.T) {
+	t.Parallel()
+
+	reset := time.Now().Add(time.Second).Truncate(time.Second).Add(time.Second)
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Ratelimit-Limit", "800")
+		w.Header().Set("Ratelimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Ratelimit-Remaining", "0")
+		} else {
+			w.Header().Set("Ratelimit-Remaining", "799")
+		}
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	client := newTestSubscriptionClient(server.URL)
+	_, err := client.Subscribe(context.Background(), validSubscribeRequest())
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = client.Subscribe(ctx, validSubscribeRequest())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "request should wait for the reset")
+}
+
+func TestSubscribeEventUrlSharesRateLimit(t *testing.T) {
+	t.Parallel()
+
+	reset := time.Now().Add(time.Second).Truncate(time.Second).Add(time.Second)
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Ratelimit-Limit", "800")
+		w.Header().Set("Ratelimit-Remaining", "0")
+		w.Header().Set("Ratelimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	_, err := twitch.SubscribeEventUrl(validSubscribeRequest(), server.URL)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = twitch.SubscribeEventUrlWithContext(ctx, validSubscribeRequest(), server.URL)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "calls for the same url should share the rate limit")
+}
@@ -0,0 +1,446 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultResubscribeAttempts = 3
+
+var ErrSubscriptionRevoked = fmt.Errorf("subscription revoked")
+
+type RevocationAction int
+
+const (
+	// RevocationDrop removes the subscription from the manager for good.
+	RevocationDrop RevocationAction = iota
+	// RevocationRetry subscribes again with backoff.
+	RevocationRetry
+	// RevocationUpgrade subscribes again with the newest known version of the event.
+	RevocationUpgrade
+)
+
+// RevocationPolicy decides what the manager does with a revoked subscription.
+type RevocationPolicy func(status SubscriptionStatus, reason RevocationReason) RevocationAction
+
+// DefaultRevocationPolicy upgrades removed versions, drops subscriptions whose user or
+// authorization is gone since subscribing again would fail, and retries anything else.
+func DefaultRevocationPolicy(status SubscriptionStatus, reason RevocationReason) RevocationAction {
+	switch reason {
+	case RevocationUserRemoved, RevocationAuthorizationRevoked:
+		return RevocationDrop
+	case RevocationVersionRemoved:
+		return RevocationUpgrade
+	default:
+		return RevocationRetry
+	}
+}
+
+// SubscriptionStatus is the state of a subscription declared on a SubscriptionManager.
+type SubscriptionStatus struct {
+	Request      SubscribeRequest
+	Subscription PayloadSubscription
+	SessionID    string
+
+	// Subscribed is true when the subscription was created or already existed for the session.
+	Subscribed bool
+	// Queued is true when the subscription is waiting for room in the budget.
+	Queued    bool
+	Err       error
+	UpdatedAt time.Time
+}
+
+// SubscriptionManager keeps a declared set of subscriptions subscribed on a Client.
+// Every session_welcome starts a new session without subscriptions, so the whole set
+// is subscribed again. A session_reconnect keeps the subscriptions since twitch
+// migrates them to the new connection, so they are not subscribed again.
+type SubscriptionManager struct {
+	Subscriber *SubscriptionClient
+
+	// RevocationPolicy decides what happens to revoked subscriptions, DefaultRevocationPolicy is used if nil.
+	RevocationPolicy RevocationPolicy
+	// ResubscribeAttempts is how many times a revoked subscription is subscribed again before giving up.
+	ResubscribeAttempts int
+
+	// QueueOverBudget queues subscriptions added over the budget until there is room instead of refusing them.
+	QueueOverBudget bool
+
+	client *Client
+	budget *costBudget
+
+	// subscribed is called after the subscriptions were subscribed for a new session
+	subscribed func()
+
+	mu            sync.Mutex
+	sessionID     string
+	migratedFrom  string
+	subscriptions []*SubscriptionStatus
+}
+
+// NewSubscriptionManager attaches a new manager to the client.
+// A client with a manager can connect without setting OnWelcome.
+func NewSubscriptionManager(client *Client) *SubscriptionManager {
+	manager := &SubscriptionManager{
+		Subscriber: NewSubscriptionClient(),
+
+		ResubscribeAttempts: defaultResubscribeAttempts,
+
+		client: client,
+		budget: &costBudget{maxCount: maxWebsocketSubscriptions},
+	}
+	client.subscriptionManager = manager
+	return manager
+}
+
+// Add declares a subscription. If a session is active it is subscribed right away.
+// The SessionID of the request is filled in by the manager. A subscription that would
+// exceed the budget is refused with a *BudgetExceededError unless QueueOverBudget is set.
+func (m *SubscriptionManager) Add(ctx context.Context, request SubscribeRequest) error {
+	key, err := subscriptionKey(request)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	for _, status := range m.subscriptions {
+		if existing, _ := subscriptionKey(status.Request); existing == key {
+			m.mu.Unlock()
+			return nil
+		}
+	}
+
+	status := &SubscriptionStatus{Request: request}
+	m.subscriptions = append(m.subscriptions, status)
+	sessionID := m.sessionID
+	m.mu.Unlock()
+
+	if sessionID == "" {
+		return nil
+	}
+
+	err = m.subscribe(ctx, sessionID, []*SubscriptionStatus{status})
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var budgetErr *BudgetExceededError
+	if errors.As(status.Err, &budgetErr) && !m.QueueOverBudget {
+		m.removeStatus(status)
+		return budgetErr
+	}
+	return err
+}
+
+// Remove stops declaring a subscription and deletes it from twitch if it was subscribed.
+func (m *SubscriptionManager) Remove(ctx context.Context, request SubscribeRequest) error {
+	key, err := subscriptionKey(request)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	var removed *SubscriptionStatus
+	for i, status := range m.subscriptions {
+		if existing, _ := subscriptionKey(status.Request); existing == key {
+			removed = status
+			m.subscriptions = append(m.subscriptions[:i], m.subscriptions[i+1:]...)
+			break
+		}
+	}
+	m.mu.Unlock()
+
+	if removed == nil || !removed.Subscribed {
+		return nil
+	}
+
+	m.client.registry.remove(removed.Subscription.ID)
+	err = m.Subscriber.DeleteSubscription(ctx, removed.Request.ClientID, removed.Request.AccessToken, removed.Subscription.ID)
+	if err != nil {
+		return err
+	}
+
+	m.budget.remove(removed.Subscription.Cost)
+	return m.drainQueue(ctx)
+}
+
+// BudgetUsage is how much of the connection's subscription budget is used.
+type BudgetUsage struct {
+	TotalCost    int
+	MaxTotalCost int
+
+	Subscriptions    int
+	MaxSubscriptions int
+
+	// Queued is how many subscriptions are waiting for room in the budget.
+	Queued int
+}
+
+// Usage returns the budget used by the current session.
+// MaxTotalCost is 0 until twitch has responded to a subscription.
+func (m *SubscriptionManager) Usage() BudgetUsage {
+	var usage BudgetUsage
+	usage.TotalCost, usage.MaxTotalCost, usage.Subscriptions, usage.MaxSubscriptions = m.budget.usage()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, status := range m.subscriptions {
+		if status.Queued {
+			usage.Queued++
+		}
+	}
+	return usage
+}
+
+// drainQueue subscribes queued subscriptions now that there might be room for them.
+func (m *SubscriptionManager) drainQueue(ctx context.Context) error {
+	m.mu.Lock()
+	sessionID := m.sessionID
+	var queued []*SubscriptionStatus
+	for _, status := range m.subscriptions {
+		if status.Queued {
+			queued = append(queued, status)
+		}
+	}
+	m.mu.Unlock()
+
+	if sessionID == "" {
+		return nil
+	}
+	return m.subscribe(ctx, sessionID, queued)
+}
+
+// removeStatus takes the status out of the declared set, m.mu must be held.
+func (m *SubscriptionManager) removeStatus(status *SubscriptionStatus) {
+	for i, s := range m.subscriptions {
+		if s == status {
+			m.subscriptions = append(m.subscriptions[:i], m.subscriptions[i+1:]...)
+			return
+		}
+	}
+}
+
+// Status returns a snapshot of every declared subscription in the order they were added.
+func (m *SubscriptionManager) Status() []SubscriptionStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	statuses := make([]SubscriptionStatus, len(m.subscriptions))
+	for i, status := range m.subscriptions {
+		statuses[i] = *status
+	}
+	return statuses
+}
+
+// SessionID returns the session the subscriptions were last subscribed on.
+func (m *SubscriptionManager) SessionID() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sessionID
+}
+
+func (m *SubscriptionManager) handleWelcome(ctx context.Context, message WelcomeMessage) {
+	m.mu.Lock()
+	m.sessionID = message.Payload.Session.ID
+	statuses := append([]*SubscriptionStatus(nil), m.subscriptions...)
+	for _, status := range statuses {
+		status.Subscribed = false
+	}
+	m.mu.Unlock()
+
+	m.budget.reset()
+
+	err := m.subscribe(ctx, message.Payload.Session.ID, statuses)
+	if err != nil {
+		m.client.onError(err)
+	}
+
+	if m.subscribed != nil {
+		m.subscribed()
+	}
+}
+
+// handleSessionReconnect updates the session without subscribing since twitch moves the subscriptions over.
+func (m *SubscriptionManager) handleSessionReconnect(message WelcomeMessage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.migratedFrom = m.sessionID
+	m.sessionID = message.Payload.Session.ID
+	for _, status := range m.subscriptions {
+		status.SessionID = m.sessionID
+	}
+}
+
+func (m *SubscriptionManager) handleRevoke(ctx context.Context, message RevokeMessage) {
+	reason := message.Reason()
+	revoked := message.Payload.Subscription
+
+	policy := m.RevocationPolicy
+	if policy == nil {
+		policy = DefaultRevocationPolicy
+	}
+
+	m.mu.Lock()
+	index := -1
+	for i, status := range m.subscriptions {
+		if status.Subscription.ID != "" && status.Subscription.ID == revoked.ID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		m.mu.Unlock()
+		return
+	}
+
+	status := m.subscriptions[index]
+	status.Subscribed = false
+	status.Err = fmt.Errorf("%w: %s", ErrSubscriptionRevoked, reason)
+	status.UpdatedAt = orSystemClock(m.Subscriber.Clock).Now()
+	snapshot := *status
+	m.mu.Unlock()
+
+	// The policy is called without the lock so it can use the manager
+	action := policy(snapshot, reason)
+
+	m.mu.Lock()
+	if !m.declared(status) {
+		// The policy removed it
+		action = RevocationDrop
+	}
+	if action == RevocationUpgrade {
+		metadata, ok := subMetadata[status.Request.Event]
+		if ok && metadata.Version != revoked.Version {
+			status.Request.VersionOverride = ""
+		} else {
+			action = RevocationDrop
+		}
+	}
+
+	if action == RevocationDrop {
+		m.removeStatus(status)
+	}
+	m.mu.Unlock()
+
+	m.budget.remove(revoked.Cost)
+
+	if action == RevocationDrop {
+		err := m.drainQueue(ctx)
+		if err != nil {
+			m.client.onError(err)
+		}
+		return
+	}
+
+	var err error
+	for attempt := 0; attempt < m.ResubscribeAttempts; attempt++ {
+		if attempt > 0 {
+			err = sleepContext(ctx, m.Subscriber.Clock, m.Subscriber.backoff(attempt-1, 0))
+			if err != nil {
+				break
+			}
+		}
+
+		m.mu.Lock()
+		sessionID := m.sessionID
+		declared := m.declared(status)
+		m.mu.Unlock()
+		if !declared {
+			return
+		}
+
+		err = m.subscribe(ctx, sessionID, []*SubscriptionStatus{status})
+		if err == nil {
+			// Subscribed or queued until there is room in the budget
+			return
+		}
+	}
+
+	if err != nil {
+		m.client.onError(fmt.Errorf("could not resubscribe revoked %s subscription: %w", status.Request.Event, err))
+	}
+}
+
+// declared reports if the status is still in the declared set, m.mu must be held.
+func (m *SubscriptionManager) declared(status *SubscriptionStatus) bool {
+	for _, s := range m.subscriptions {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *SubscriptionManager) subscribe(ctx context.Context, sessionID string, statuses []*SubscriptionStatus) error {
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	m.mu.Lock()
+	requests := make([]SubscribeRequest, len(statuses))
+	for i, status := range statuses {
+		requests[i] = status.Request
+	}
+	m.mu.Unlock()
+
+	results, _ := m.Subscriber.subscribeMany(ctx, sessionID, requests, m.budget)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// A session_reconnect could have happened while subscribing
+	if sessionID == m.migratedFrom {
+		sessionID = m.sessionID
+	}
+
+	var failed []SubscribeResult
+	now := orSystemClock(m.Subscriber.Clock).Now()
+	for i, result := range results {
+		var budgetErr *BudgetExceededError
+
+		status := statuses[i]
+		status.Subscription = result.Subscription
+		status.SessionID = sessionID
+		status.Subscribed = result.Err == nil
+		status.Queued = m.QueueOverBudget && errors.As(result.Err, &budgetErr)
+		status.Err = result.Err
+		status.UpdatedAt = now
+
+		if result.Err == nil {
+			m.client.registry.track(result.Request, result.Subscription)
+		} else if !status.Queued {
+			failed = append(failed, result)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("could not subscribe managed subscriptions: %w", &SubscribeManyError{Failed: failed})
+	}
+	return nil
+}
+
+// subscriptionKey identifies a declared subscription by its event, version and condition.
+func subscriptionKey(request SubscribeRequest) (string, error) {
+	condition, err := request.condition()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s|%s|%s", request.Event, request.VersionOverride, conditionKey(condition)), nil
+}
+
+// conditionKey is the condition as a string with the keys sorted and empty values left out.
+func conditionKey(condition map[string]string) string {
+	var pairs []string
+	for key, value := range condition {
+		if value != "" {
+			pairs = append(pairs, fmt.Sprintf("%s=%s", key, value))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
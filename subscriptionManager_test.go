@@ -0,0 +1,270 @@
+package twitch_test
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSubscriptionManager(t *testing.T, client *twitch.Client) (*twitch.SubscriptionManager, *fakeHelixSubscriptions) {
+	helix := &fakeHelixSubscriptions{maxTotalCost: 10}
+	server := httptest.NewServer(helix)
+	t.Cleanup(server.Close)
+
+	manager := twitch.NewSubscriptionManager(client)
+	manager.Subscriber = newTestSubscriptionClient(server.URL)
+	return manager, helix
+}
+
+func allSubscribed(manager *twitch.SubscriptionManager) bool {
+	statuses := manager.Status()
+	for _, status := range statuses {
+		if !status.Subscribed || status.SessionID != manager.SessionID() {
+			return false
+		}
+	}
+	return len(statuses) > 0
+}
+
+func TestSubscriptionManagerSubscribesOnWelcome(t *testing.T) {
+	t.Parallel()
+
+	client := newClient(t, noDataGen)
+	manager, helix := newTestSubscriptionManager(t, client)
+
+	assert.NoError(t, manager.Add(context.Background(), onlineRequest("1")))
+	assert.NoError(t, manager.Add(context.Background(), onlineRequest("1")), "duplicates are ignored")
+	assert.NoError(t, manager.Add(context.Background(), twitch.SubscribeRequest{
+		Event:          twitch.SubStreamOffline,
+		TypedCondition: twitch.BroadcasterCondition{BroadcasterUserID: "1"},
+	}))
+
+	go connect(t, client)
+	defer client.Close()
+
+	assert.Eventually(t, func() bool { return allSubscribed(manager) }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, helix.postCount())
+	assert.NotEmpty(t, manager.Status()[0].Subscription.ID)
+
+	assert.NoError(t, manager.Add(context.Background(), onlineRequest("2")))
+	assert.Equal(t, 3, helix.postCount(), "adding while connected subscribes right away")
+	assert.True(t, manager.Status()[2].Subscribed)
+
+	assert.NoError(t, manager.Remove(context.Background(), onlineRequest("2")))
+	assert.Len(t, manager.Status(), 2)
+}
+
+func TestSubscriptionManagerSkipsSessionReconnect(t *testing.T) {
+	t.Parallel()
+
+	reconnectServer, err := newTestServer(keepAliveGen)
+	if err != nil {
+		t.Fatalf("could not create reconnect server: %v", err)
+	}
+	reconnectUrl := fmt.Sprintf("http://%s/%s", reconnectServer.Address, "ws")
+
+	client := newClient(t, genReconnectGen(reconnectUrl))
+	manager, helix := newTestSubscriptionManager(t, client)
+	assert.NoError(t, manager.Add(context.Background(), onlineRequest("1")))
+
+	firstSession := make(chan string, 1)
+	client.OnWelcome(func(message twitch.WelcomeMessage) {
+		firstSession <- message.Payload.Session.ID
+	})
+	client.OnKeepAlive(func(message twitch.KeepAliveMessage) {
+		client.Close()
+	})
+
+	err = client.Connect()
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool { return allSubscribed(manager) }, time.Second, 10*time.Millisecond)
+	assert.NotEqual(t, <-firstSession, manager.SessionID(), "session should come from the reconnect welcome")
+	assert.Equal(t, 1, helix.postCount(), "subscriptions should not be recreated after a session_reconnect")
+}
+
+func TestRevokeMessageReason(t *testing.T) {
+	t.Parallel()
+
+	var message twitch.RevokeMessage
+	message.Payload.Subscription.Status = "user_removed"
+	assert.Equal(t, twitch.RevocationUserRemoved, message.Reason())
+}
+
+func TestSubscriptionManagerRevocation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name    string
+		Reason  twitch.RevocationReason
+		Request twitch.SubscribeRequest
+		Posts   int
+		Version string
+	}{
+		{"Drop", twitch.RevocationUserRemoved, onlineRequest("1"), 1, ""},
+		{"Retry", twitch.RevocationNotificationFailuresExceeded, onlineRequest("1"), 2, "1"},
+		{"Upgrade", twitch.RevocationVersionRemoved, twitch.SubscribeRequest{
+			Event:           twitch.SubChannelUpdate,
+			VersionOverride: "1",
+			TypedCondition:  twitch.BroadcasterCondition{BroadcasterUserID: "1"},
+		}, 2, "2"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			url, frames := newScriptedServer(t)
+			client := twitch.NewClientWithUrl(url)
+			client.OnError(func(err error) { t.Errorf("client registered an error: %v", err) })
+
+			manager, helix := newTestSubscriptionManager(t, client)
+			assert.NoError(t, manager.Add(context.Background(), tc.Request))
+
+			go connect(t, client)
+			defer client.Close()
+
+			assert.Eventually(t, func() bool { return allSubscribed(manager) }, time.Second, 10*time.Millisecond)
+			revoked := manager.Status()[0].Subscription
+
+			helix.revoke(revoked.ID)
+			frames <- newRevocation(revoked, tc.Reason)
+
+			if tc.Posts == 1 {
+				assert.Eventually(t, func() bool { return len(manager.Status()) == 0 }, time.Second, 10*time.Millisecond)
+				assert.Equal(t, 1, helix.postCount())
+				return
+			}
+
+			assert.Eventually(t, func() bool {
+				statuses := manager.Status()
+				return len(statuses) == 1 && statuses[0].Subscribed && statuses[0].Subscription.ID != revoked.ID
+			}, time.Second, 10*time.Millisecond)
+			assert.Equal(t, tc.Posts, helix.postCount())
+			assert.Equal(t, tc.Version, manager.Status()[0].Subscription.Version)
+		})
+	}
+}
+
+func TestSubscriptionManagerRevocationPolicyUsesManager(t *testing.T) {
+	t.Parallel()
+
+	url, frames := newScriptedServer(t)
+	client := twitch.NewClientWithUrl(url)
+	client.OnError(func(err error) { t.Errorf("client registered an error: %v", err) })
+
+	manager, helix := newTestSubscriptionManager(t, client)
+	assert.NoError(t, manager.Add(context.Background(), onlineRequest("1")))
+	assert.NoError(t, manager.Add(context.Background(), onlineRequest("2")))
+
+	policyStatuses := make(chan int, 1)
+	manager.RevocationPolicy = func(status twitch.SubscriptionStatus, reason twitch.RevocationReason) twitch.RevocationAction {
+		policyStatuses <- len(manager.Status())
+		assert.NoError(t, manager.Remove(context.Background(), onlineRequest("2")))
+		return twitch.RevocationRetry
+	}
+
+	go connect(t, client)
+	defer client.Close()
+
+	assert.Eventually(t, func() bool { return allSubscribed(manager) }, time.Second, 10*time.Millisecond)
+	revoked := manager.Status()[0].Subscription
+
+	helix.revoke(revoked.ID)
+	frames <- newRevocation(revoked, twitch.RevocationNotificationFailuresExceeded)
+
+	select {
+	case count := <-policyStatuses:
+		assert.Equal(t, 2, count)
+	case <-time.After(time.Second):
+		t.Fatal("policy was not called")
+	}
+
+	assert.Eventually(t, func() bool {
+		statuses := manager.Status()
+		return len(statuses) == 1 && statuses[0].Subscribed && statuses[0].Subscription.ID != revoked.ID
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSubscriptionManagerBudget(t *testing.T) {
+	t.Parallel()
+
+	client := newClient(t, noDataGen)
+	manager, helix := newTestSubscriptionManager(t, client)
+	manager.Subscriber.MaxConcurrency = 1
+	helix.maxTotalCost = 2
+	manager.QueueOverBudget = true
+
+	for _, userID := range []string{"1", "2", "3"} {
+		assert.NoError(t, manager.Add(context.Background(), onlineRequest(userID)))
+	}
+
+	go connect(t, client)
+	defer client.Close()
+
+	assert.Eventually(t, func() bool { return manager.Usage().Queued == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, twitch.BudgetUsage{
+		TotalCost:        2,
+		MaxTotalCost:     2,
+		Subscriptions:    2,
+		MaxSubscriptions: 300,
+		Queued:           1,
+	}, manager.Usage())
+
+	queued := manager.Status()[2]
+	assert.True(t, queued.Queued)
+	assert.ErrorIs(t, queued.Err, twitch.ErrMaxTotalCostExceeded)
+
+	manager.QueueOverBudget = false
+	err := manager.Add(context.Background(), onlineRequest("4"))
+
+	var budgetErr *twitch.BudgetExceededError
+	if assert.ErrorAs(t, err, &budgetErr) {
+		assert.Equal(t, twitch.SubStreamOnline, budgetErr.Event)
+		assert.Equal(t, 2, budgetErr.MaxTotalCost)
+	}
+	assert.Len(t, manager.Status(), 3, "refused subscriptions are not declared")
+
+	assert.NoError(t, manager.Remove(context.Background(), onlineRequest("1")))
+	assert.Equal(t, 0, manager.Usage().Queued, "queued subscription should take the freed room")
+	assert.True(t, manager.Status()[1].Subscribed)
+	assert.Equal(t, 2, manager.Usage().TotalCost)
+}
+
+func TestSubscriptionManagerRefusesOverBudgetOnWelcome(t *testing.T) {
+	t.Parallel()
+
+	client := newClient(t, noDataGen)
+	manager, helix := newTestSubscriptionManager(t, client)
+	manager.Subscriber.MaxConcurrency = 1
+	helix.maxTotalCost = 2
+
+	for _, userID := range []string{"1", "2", "3"} {
+		assert.NoError(t, manager.Add(context.Background(), onlineRequest(userID)))
+	}
+
+	errs := make(chan error, 1)
+	client.OnError(func(err error) { errs <- err })
+
+	go connect(t, client)
+	defer client.Close()
+
+	select {
+	case err := <-errs:
+		assert.ErrorIs(t, err, twitch.ErrMaxTotalCostExceeded)
+	case <-time.After(time.Second):
+		t.Fatal("refused subscription was not reported")
+	}
+
+	refused := manager.Status()[2]
+	assert.False(t, refused.Queued)
+	assert.False(t, refused.Subscribed)
+	assert.ErrorIs(t, refused.Err, twitch.ErrMaxTotalCostExceeded)
+	assert.Equal(t, 0, manager.Usage().Queued)
+}
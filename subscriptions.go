@@ -1,415 +1,120 @@
 package twitch
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
+	"sort"
+	"strings"
 )
 
+//go:generate go run ./internal/eventgen
+
 const twitchEventSubUrl = "https://api.twitch.tv/helix/eventsub/subscriptions"
 
 type EventSubscription string
 
+type TransportMethod string
+
+const (
+	TransportWebsocket TransportMethod = "websocket"
+	TransportWebhook   TransportMethod = "webhook"
+	TransportConduit   TransportMethod = "conduit"
+)
+
+// SubscriptionCost describes how a subscription counts against the max_total_cost of a transport.
+type SubscriptionCost int
+
+const (
+	// CostAuthorized subscriptions require the user in the condition to authorize the client, so they cost 0.
+	CostAuthorized SubscriptionCost = iota
+	// CostUnauthorized subscriptions cost 1 unless a user in the condition has authorized the client.
+	CostUnauthorized
+)
+
 var (
-	SubChannelUpdate EventSubscription = "channel.update"
-	SubChannelFollow EventSubscription = "channel.follow"
-
-	SubChannelSubscribe           EventSubscription = "channel.subscribe"
-	SubChannelSubscriptionEnd     EventSubscription = "channel.subscription.end"
-	SubChannelSubscriptionGift    EventSubscription = "channel.subscription.gift"
-	SubChannelSubscriptionMessage EventSubscription = "channel.subscription.message"
-
-	SubChannelCheer EventSubscription = "channel.cheer"
-	SubChannelRaid  EventSubscription = "channel.raid"
-	SubChannelBan   EventSubscription = "channel.ban"
-	SubChannelUnban EventSubscription = "channel.unban"
-
-	SubChannelModeratorAdd    EventSubscription = "channel.moderator.add"
-	SubChannelModeratorRemove EventSubscription = "channel.moderator.remove"
-	SubChannelVIPAdd          EventSubscription = "channel.vip.add"
-	SubChannelVIPRemove       EventSubscription = "channel.vip.remove"
-
-	SubChannelChannelPointsCustomRewardAdd              EventSubscription = "channel.channel_points_custom_reward.add"
-	SubChannelChannelPointsCustomRewardUpdate           EventSubscription = "channel.channel_points_custom_reward.update"
-	SubChannelChannelPointsCustomRewardRemove           EventSubscription = "channel.channel_points_custom_reward.remove"
-	SubChannelChannelPointsCustomRewardRedemptionAdd    EventSubscription = "channel.channel_points_custom_reward_redemption.add"
-	SubChannelChannelPointsCustomRewardRedemptionUpdate EventSubscription = "channel.channel_points_custom_reward_redemption.update"
-	SubChannelChannelPointsAutomaticRewardRedemptionAdd EventSubscription = "channel.channel_points_automatic_reward_redemption.add"
-
-	SubChannelPollBegin    EventSubscription = "channel.poll.begin"
-	SubChannelPollProgress EventSubscription = "channel.poll.progress"
-	SubChannelPollEnd      EventSubscription = "channel.poll.end"
-
-	SubChannelPredictionBegin    EventSubscription = "channel.prediction.begin"
-	SubChannelPredictionProgress EventSubscription = "channel.prediction.progress"
-	SubChannelPredictionLock     EventSubscription = "channel.prediction.lock"
-	SubChannelPredictionEnd      EventSubscription = "channel.prediction.end"
-
-	SubDropEntitlementGrant           EventSubscription = "drop.entitlement.grant"
-	SubExtensionBitsTransactionCreate EventSubscription = "extension.bits_transaction.create"
-
-	SubChannelGoalBegin    EventSubscription = "channel.goal.begin"
-	SubChannelGoalProgress EventSubscription = "channel.goal.progress"
-	SubChannelGoalEnd      EventSubscription = "channel.goal.end"
-
-	SubChannelHypeTrainBegin    EventSubscription = "channel.hype_train.begin"
-	SubChannelHypeTrainProgress EventSubscription = "channel.hype_train.progress"
-	SubChannelHypeTrainEnd      EventSubscription = "channel.hype_train.end"
-
-	SubStreamOnline  EventSubscription = "stream.online"
-	SubStreamOffline EventSubscription = "stream.offline"
-
-	SubUserAuthorizationGrant  EventSubscription = "user.authorization.grant"
-	SubUserAuthorizationRevoke EventSubscription = "user.authorization.revoke"
-	SubUserUpdate              EventSubscription = "user.update"
-
-	SubChannelCharityCampaignDonate   EventSubscription = "channel.charity_campaign.donate"
-	SubChannelCharityCampaignStart    EventSubscription = "channel.charity_campaign.start"
-	SubChannelCharityCampaignProgress EventSubscription = "channel.charity_campaign.progress"
-	SubChannelCharityCampaignStop     EventSubscription = "channel.charity_campaign.stop"
-
-	SubChannelShieldModeBegin EventSubscription = "channel.shield_mode.begin"
-	SubChannelShieldModeEnd   EventSubscription = "channel.shield_mode.end"
-
-	SubChannelShoutoutCreate  EventSubscription = "channel.shoutout.create"
-	SubChannelShoutoutReceive EventSubscription = "channel.shoutout.receive"
-
-	SubChannelModerate EventSubscription = "channel.moderate"
-
-	SubAutomodMessageHold           EventSubscription = "automod.message.hold"
-	SubAutomodMessageUpdate         EventSubscription = "automod.message.update"
-	SubAutomodSettingsUpdate        EventSubscription = "automod.settings.update"
-	SubAutomodTermsUpdate           EventSubscription = "automod.terms.update"
-	SubChannelChatUserMessageHold   EventSubscription = "channel.chat.user_message_hold"
-	SubChannelChatUserMessageUpdate EventSubscription = "channel.chat.user_message_update"
-
-	SubChannelChatClear             EventSubscription = "channel.chat.clear"
-	SubChannelChatClearUserMessages EventSubscription = "channel.chat.clear_user_messages"
-	SubChannelChatMessage           EventSubscription = "channel.chat.message"
-	SubChannelChatMessageDelete     EventSubscription = "channel.chat.message_delete"
-	SubChannelChatNotification      EventSubscription = "channel.chat.notification"
-	SubChannelChatSettingsUpdate    EventSubscription = "channel.chat_settings.update"
-	SubChannelSuspiciousUserMessage EventSubscription = "channel.suspicious_user.message"
-	SubChannelSuspiciousUserUpdate  EventSubscription = "channel.suspicious_user.update"
-
-	SubChannelSharedChatBegin  EventSubscription = "channel.shared_chat.begin"
-	SubChannelSharedChatUpdate EventSubscription = "channel.shared_chat.update"
-	SubChannelSharedChatEnd    EventSubscription = "channel.shared_chat.end"
-
-	SubUserWhisperMessage EventSubscription = "user.whisper.message"
-
-	SubChannelAdBreakBegin EventSubscription = "channel.ad_break.begin"
-
-	SubChannelWarningAcknowledge EventSubscription = "channel.warning.acknowledge"
-	SubChannelWarningSend        EventSubscription = "channel.warning.send"
-
-	SubChannelUnbanRequestCreate  EventSubscription = "channel.unban_request.create"
-	SubChannelUnbanRequestResolve EventSubscription = "channel.unban_request.resolve"
-
-	SubConduitShardDisabled EventSubscription = "conduit.shard.disabled"
-
-	subMetadata = map[EventSubscription]subscriptionMetadata{
-		SubChannelUpdate: {
-			Version:  "2",
-			EventGen: zeroPtrGen[EventChannelUpdate](),
-		},
-		SubChannelFollow: {
-			Version:  "2",
-			EventGen: zeroPtrGen[EventChannelFollow](),
-		},
-		SubChannelSubscribe: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelSubscribe](),
-		},
-		SubChannelSubscriptionEnd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelSubscriptionEnd](),
-		},
-		SubChannelSubscriptionGift: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelSubscriptionGift](),
-		},
-		SubChannelSubscriptionMessage: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelSubscriptionMessage](),
-		},
-		SubChannelCheer: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelCheer](),
-		},
-		SubChannelRaid: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelRaid](),
-		},
-		SubChannelBan: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelBan](),
-		},
-		SubChannelUnban: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelUnban](),
-		},
-		SubChannelModeratorAdd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelModeratorAdd](),
-		},
-		SubChannelModeratorRemove: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelModeratorRemove](),
-		},
-		SubChannelVIPAdd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelVIPAdd](),
-		},
-		SubChannelVIPRemove: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelVIPRemove](),
-		},
-		SubChannelChannelPointsCustomRewardAdd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelChannelPointsCustomRewardAdd](),
-		},
-		SubChannelChannelPointsCustomRewardUpdate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelChannelPointsCustomRewardUpdate](),
-		},
-		SubChannelChannelPointsCustomRewardRemove: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelChannelPointsCustomRewardRemove](),
-		},
-		SubChannelChannelPointsCustomRewardRedemptionAdd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelChannelPointsCustomRewardRedemptionAdd](),
-		},
-		SubChannelChannelPointsCustomRewardRedemptionUpdate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelChannelPointsCustomRewardRedemptionUpdate](),
-		},
-		SubChannelChannelPointsAutomaticRewardRedemptionAdd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelChannelPointsAutomaticRewardRedemptionAdd](),
-		},
-		SubChannelPollBegin: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelPollBegin](),
-		},
-		SubChannelPollProgress: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelPollProgress](),
-		},
-		SubChannelPollEnd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelPollEnd](),
-		},
-		SubChannelPredictionBegin: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelPredictionBegin](),
-		},
-		SubChannelPredictionProgress: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelPredictionProgress](),
-		},
-		SubChannelPredictionLock: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelPredictionLock](),
-		},
-		SubChannelPredictionEnd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelPredictionEnd](),
-		},
-		SubDropEntitlementGrant: {
-			Version:  "1",
-			EventGen: zeroPtrGen[[]EventDropEntitlementGrant](), //func() any { return &[]EventDropEntitlementGrant{} },
-		},
-		SubExtensionBitsTransactionCreate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventExtensionBitsTransactionCreate](),
-		},
-		SubChannelGoalBegin: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelGoalBegin](),
-		},
-		SubChannelGoalProgress: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelGoalProgress](),
-		},
-		SubChannelGoalEnd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelGoalEnd](),
-		},
-		SubChannelHypeTrainBegin: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelHypeTrainBegin](),
-		},
-		SubChannelHypeTrainProgress: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelHypeTrainProgress](),
-		},
-		SubChannelHypeTrainEnd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelHypeTrainEnd](),
-		},
-		SubStreamOnline: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventStreamOnline](),
-		},
-		SubStreamOffline: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventStreamOffline](),
-		},
-		SubUserAuthorizationGrant: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventUserAuthorizationGrant](),
-		},
-		SubUserAuthorizationRevoke: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventUserAuthorizationRevoke](),
-		},
-		SubUserUpdate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventUserUpdate](),
-		},
-		SubChannelCharityCampaignDonate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelCharityCampaignDonate](),
-		},
-		SubChannelCharityCampaignStart: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelCharityCampaignStart](),
-		},
-		SubChannelCharityCampaignProgress: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelCharityCampaignProgress](),
-		},
-		SubChannelCharityCampaignStop: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelCharityCampaignStop](),
-		},
-		SubChannelShieldModeBegin: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelShieldModeBegin](),
-		},
-		SubChannelShieldModeEnd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelShieldModeEnd](),
-		},
-		SubChannelShoutoutCreate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelShoutoutCreate](),
-		},
-		SubChannelShoutoutReceive: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelShoutoutReceive](),
-		},
-		SubChannelModerate: {
-			Version:  "2",
-			EventGen: zeroPtrGen[EventChannelModerate](),
-		},
-		SubAutomodMessageHold: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventAutomodMessageHold](),
-		},
-		SubAutomodMessageUpdate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventAutomodMessageUpdate](),
-		},
-		SubAutomodSettingsUpdate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventAutomodSettingsUpdate](),
-		},
-		SubAutomodTermsUpdate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventAutomodTermsUpdate](),
-		},
-		SubChannelChatUserMessageHold: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelChatUserMessageHold](),
-		},
-		SubChannelChatUserMessageUpdate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelChatUserMessageUpdate](),
-		},
-		SubChannelChatClear: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelChatClear](),
-		},
-		SubChannelChatClearUserMessages: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelChatClearUserMessages](),
-		},
-		SubChannelChatMessage: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelChatMessage](),
-		},
-		SubChannelChatMessageDelete: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelChatMessageDelete](),
-		},
-		SubChannelChatNotification: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelChatNotification](),
-		},
-		SubChannelChatSettingsUpdate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelChatSettingsUpdate](),
-		},
-		SubChannelSuspiciousUserMessage: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelSuspiciousUserMessage](),
-		},
-		SubChannelSuspiciousUserUpdate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelSuspiciousUserUpdate](),
-		},
-		SubChannelSharedChatBegin: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelSharedChatBegin](),
-		},
-		SubChannelSharedChatUpdate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelSharedChatUpdate](),
-		},
-		SubChannelSharedChatEnd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelSharedChatEnd](),
-		},
-		SubUserWhisperMessage: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventUserWhisperMessage](),
-		},
-		SubChannelAdBreakBegin: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelAdBreakBegin](),
-		},
-		SubChannelWarningAcknowledge: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelWarningAcknowledge](),
-		},
-		SubChannelWarningSend: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelWarningSend](),
-		},
-		SubChannelUnbanRequestCreate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelUnbanRequestCreate](),
-		},
-		SubChannelUnbanRequestResolve: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelUnbanRequestResolve](),
-		},
-		SubConduitShardDisabled: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventConduitShardDisabled](),
-		},
-	}
+	userTransports = []TransportMethod{TransportWebsocket, TransportWebhook, TransportConduit}
+	appTransports  = []TransportMethod{TransportWebhook, TransportConduit}
 )
 
 type subscriptionMetadata struct {
 	Version  string
 	EventGen func() interface{}
+
+	RequiredConditions []string
+	OptionalConditions []string
+	OneOfConditions    []string
+	Scopes             [][]string
+	Transports         []TransportMethod
+	Cost               SubscriptionCost
+}
+
+// SubscriptionDetails describes what twitch expects when subscribing to an event type.
+type SubscriptionDetails struct {
+	Type    EventSubscription
+	Version string
+
+	// RequiredConditions must all be set in the subscription condition.
+	RequiredConditions []string
+	// OptionalConditions may be set to narrow down the events received.
+	OptionalConditions []string
+	// OneOfConditions lists keys of which exactly one must be set, e.g. the from or to broadcaster of a raid.
+	OneOfConditions []string
+
+	// Scopes lists the OAuth scopes needed by the authorizing user.
+	// Every entry must be satisfied, and each entry lists the alternative scopes that satisfy it.
+	Scopes [][]string
+
+	Transports []TransportMethod
+	Cost       SubscriptionCost
+}
+
+// SupportsTransport reports if the event type can be delivered over the transport method.
+func (d SubscriptionDetails) SupportsTransport(method TransportMethod) bool {
+	return containsTransport(d.Transports, method)
+}
+
+func containsTransport(transports []TransportMethod, method TransportMethod) bool {
+	for _, transport := range transports {
+		if transport == method {
+			return true
+		}
+	}
+	return false
+}
+
+// SubscriptionInfo returns the details of a known subscription type.
+func SubscriptionInfo(event EventSubscription) (SubscriptionDetails, bool) {
+	metadata, ok := subMetadata[event]
+	if !ok {
+		return SubscriptionDetails{}, false
+	}
+
+	scopes := make([][]string, len(metadata.Scopes))
+	for i, alternatives := range metadata.Scopes {
+		scopes[i] = append([]string(nil), alternatives...)
+	}
+
+	return SubscriptionDetails{
+		Type:               event,
+		Version:            metadata.Version,
+		RequiredConditions: append([]string(nil), metadata.RequiredConditions...),
+		OptionalConditions: append([]string(nil), metadata.OptionalConditions...),
+		OneOfConditions:    append([]string(nil), metadata.OneOfConditions...),
+		Scopes:             scopes,
+		Transports:         append([]TransportMethod(nil), metadata.Transports...),
+		Cost:               metadata.Cost,
+	}, true
+}
+
+// SubscriptionTypes returns every known subscription type sorted by name.
+func SubscriptionTypes() []EventSubscription {
+	types := make([]EventSubscription, 0, len(subMetadata))
+	for event := range subMetadata {
+		types = append(types, event)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
 }
 
 type SubscribeRequest struct {
@@ -418,75 +123,180 @@ type SubscribeRequest struct {
 	AccessToken     string
 	VersionOverride string
 
+	// DeclaredTokenType is the kind of AccessToken according to the caller. When it is set it is
+	// checked against the transport, the AccessToken itself is not looked at. ValidateToken
+	// on a SubscriptionClient can tell which kind a token is.
+	DeclaredTokenType TokenType
+
+	// Transport is the method used to deliver events, websocket is used if empty.
+	Transport TransportMethod
+	// ConduitID is the conduit to deliver events to with the conduit transport.
+	ConduitID string
+	// Callback and Secret are used with the webhook transport.
+	Callback string
+	Secret   string
+
 	Event     EventSubscription
 	Condition map[string]string
-}
 
-type SubscribeResponse struct {
-	Data         []PayloadSubscription `json:"data"`
-	Total        int                   `json:"total"`
-	TotalCost    int                   `json:"total_cost"`
-	MaxTotalCost int                   `json:"max_total_cost"`
+	// TypedCondition can be set instead of Condition to build the condition from a typed struct.
+	TypedCondition Condition
 }
 
-func SubscribeEvent(request SubscribeRequest) (SubscribeResponse, error) {
-	return SubscribeEventUrlWithContext(context.Background(), request, twitchEventSubUrl)
+func (r SubscribeRequest) transport() TransportMethod {
+	if r.Transport == "" {
+		return TransportWebsocket
+	}
+	return r.Transport
 }
 
-func SubscribeEventUrl(request SubscribeRequest, url string) (SubscribeResponse, error) {
-	return SubscribeEventUrlWithContext(context.Background(), request, url)
+// subscriptionTransport is the transport sent to twitch for the request.
+func (r SubscribeRequest) subscriptionTransport() SubscriptionTransport {
+	transport := SubscriptionTransport{Method: string(r.transport())}
+	switch r.transport() {
+	case TransportWebsocket:
+		transport.SessionID = r.SessionID
+	case TransportWebhook:
+		transport.Callback = r.Callback
+		transport.Secret = r.Secret
+	case TransportConduit:
+		transport.ConduitID = r.ConduitID
+	}
+	return transport
 }
 
-func SubscribeEventWithContext(ctx context.Context, request SubscribeRequest) (SubscribeResponse, error) {
-	return SubscribeEventUrlWithContext(ctx, request, twitchEventSubUrl)
+// matchesTransport reports if a subscription was made with the same transport as the request.
+// The secret is left out since twitch never sends it back.
+func (r SubscribeRequest) matchesTransport(transport SubscriptionTransport) bool {
+	expected := r.subscriptionTransport()
+	expected.Secret = ""
+	transport.Secret = ""
+	return transport == expected
 }
 
-func SubscribeEventUrlWithContext(ctx context.Context, request SubscribeRequest, url string) (SubscribeResponse, error) {
-	version := subMetadata[request.Event].Version
-	if request.VersionOverride != "" {
-		version = request.VersionOverride
+func (r SubscribeRequest) condition() (map[string]string, error) {
+	if r.TypedCondition == nil {
+		return r.Condition, nil
 	}
 
-	b, err := json.Marshal(SubscriptionRequest{
-		Type:      request.Event,
-		Version:   version,
-		Condition: request.Condition,
-		Transport: SubscriptionTransport{
-			Method:    "websocket",
-			SessionID: request.SessionID,
-		},
-	})
-	if err != nil {
-		return SubscribeResponse{}, fmt.Errorf("could not convert request to json: %w", err)
+	if len(r.Condition) > 0 {
+		return nil, fmt.Errorf("only one of Condition and TypedCondition can be set")
 	}
-	buf := bytes.NewBuffer(b)
+	return r.TypedCondition.ConditionMap(), nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, buf)
-	if err != nil {
-		return SubscribeResponse{}, fmt.Errorf("could not create new request: %w", err)
+// Validate checks the request for problems twitch would reject it for.
+// All problems found are returned together in a *ValidationError.
+// The kind of access token is only checked against the transport when DeclaredTokenType
+// is set, nothing is checked about the token without it.
+func (r SubscribeRequest) Validate() error {
+	var errs []error
+
+	transport := r.transport()
+	switch transport {
+	case TransportWebsocket:
+		if r.SessionID == "" {
+			errs = append(errs, fmt.Errorf("session id is required for the websocket transport"))
+		}
+		if r.DeclaredTokenType == TokenApp {
+			errs = append(errs, fmt.Errorf("the websocket transport requires a user access token"))
+		}
+	case TransportWebhook:
+		if !strings.HasPrefix(r.Callback, "https://") {
+			errs = append(errs, fmt.Errorf("an https callback is required for the webhook transport"))
+		}
+		if len(r.Secret) < minWebhookSecretLength || len(r.Secret) > maxWebhookSecretLength {
+			errs = append(errs, fmt.Errorf("the webhook secret must be between %d and %d characters", minWebhookSecretLength, maxWebhookSecretLength))
+		}
+		if r.DeclaredTokenType == TokenUser {
+			errs = append(errs, fmt.Errorf("the webhook transport requires an app access token"))
+		}
+	case TransportConduit:
+		if r.ConduitID == "" {
+			errs = append(errs, fmt.Errorf("conduit id is required for the conduit transport"))
+		}
+		if r.DeclaredTokenType == TokenUser {
+			errs = append(errs, fmt.Errorf("the conduit transport requires an app access token"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unsupported transport %s", transport))
 	}
 
-	req.Header.Set("Client-Id", request.ClientID)
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", request.AccessToken))
-	req.Header.Set("Content-Type", "application/json")
+	metadata, known := subMetadata[r.Event]
+	switch {
+	case r.Event == "":
+		errs = append(errs, fmt.Errorf("event type is required"))
+	case !known && r.VersionOverride == "":
+		errs = append(errs, fmt.Errorf("unknown event type %s requires a version override", r.Event))
+	case known && !containsTransport(metadata.Transports, transport):
+		errs = append(errs, fmt.Errorf("%s does not support the %s transport", r.Event, transport))
+	}
 
-	resp, err := http.DefaultClient.Do(req)
+	condition, err := r.condition()
 	if err != nil {
-		return SubscribeResponse{}, fmt.Errorf("could not subscribe to event: %w", err)
+		errs = append(errs, err)
+	} else {
+		errs = append(errs, validateCondition(r.Event, condition)...)
+	}
+
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
 	}
-	defer resp.Body.Close()
+	return nil
+}
 
-	body, _ := io.ReadAll(resp.Body)
+// ValidationError holds every problem found while validating a subscribe request.
+type ValidationError struct {
+	Errors []error
+}
 
-	if resp.StatusCode != 202 {
-		return SubscribeResponse{}, fmt.Errorf("could not subscribe to event: %s: %s", resp.Status, string(body))
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
 	}
+	return fmt.Sprintf("invalid subscribe request: %s", strings.Join(messages, "; "))
+}
 
-	var subscription SubscribeResponse
-	err = json.Unmarshal(body, &subscription)
-	if err != nil {
-		return SubscribeResponse{}, fmt.Errorf("could not unmarshal subscription response: %w", err)
+// Is reports whether any of the problems matches target.
+func (e *ValidationError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
 	}
+	return false
+}
 
-	return subscription, nil
+// As finds the first problem that matches target.
+func (e *ValidationError) As(target any) bool {
+	for _, err := range e.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+type SubscribeResponse struct {
+	Data         []PayloadSubscription `json:"data"`
+	Total        int                   `json:"total"`
+	TotalCost    int                   `json:"total_cost"`
+	MaxTotalCost int                   `json:"max_total_cost"`
+}
+
+func SubscribeEvent(request SubscribeRequest) (SubscribeResponse, error) {
+	return SubscribeEventUrlWithContext(context.Background(), request, twitchEventSubUrl)
+}
+
+func SubscribeEventUrl(request SubscribeRequest, url string) (SubscribeResponse, error) {
+	return SubscribeEventUrlWithContext(context.Background(), request, url)
+}
+
+func SubscribeEventWithContext(ctx context.Context, request SubscribeRequest) (SubscribeResponse, error) {
+	return SubscribeEventUrlWithContext(ctx, request, twitchEventSubUrl)
+}
+
+func SubscribeEventUrlWithContext(ctx context.Context, request SubscribeRequest, url string) (SubscribeResponse, error) {
+	return sharedSubscriptionClient(url).Subscribe(ctx, request)
 }
@@ -0,0 +1,701 @@
+// Code generated by eventgen from subscriptions.json. DO NOT EDIT.
+
+package twitch
+
+var (
+	SubChannelUpdate    EventSubscription = "channel.update"
+	SubChannelFollow    EventSubscription = "channel.follow"
+	SubChannelSubscribe EventSubscription = "channel.subscribe"
+
+	SubChannelSubscriptionEnd     EventSubscription = "channel.subscription.end"
+	SubChannelSubscriptionGift    EventSubscription = "channel.subscription.gift"
+	SubChannelSubscriptionMessage EventSubscription = "channel.subscription.message"
+
+	SubChannelCheer EventSubscription = "channel.cheer"
+	SubChannelRaid  EventSubscription = "channel.raid"
+	SubChannelBan   EventSubscription = "channel.ban"
+	SubChannelUnban EventSubscription = "channel.unban"
+
+	SubChannelModeratorAdd    EventSubscription = "channel.moderator.add"
+	SubChannelModeratorRemove EventSubscription = "channel.moderator.remove"
+
+	SubChannelVIPAdd    EventSubscription = "channel.vip.add"
+	SubChannelVIPRemove EventSubscription = "channel.vip.remove"
+
+	SubChannelChannelPointsCustomRewardAdd    EventSubscription = "channel.channel_points_custom_reward.add"
+	SubChannelChannelPointsCustomRewardUpdate EventSubscription = "channel.channel_points_custom_reward.update"
+	SubChannelChannelPointsCustomRewardRemove EventSubscription = "channel.channel_points_custom_reward.remove"
+
+	SubChannelChannelPointsCustomRewardRedemptionAdd    EventSubscription = "channel.channel_points_custom_reward_redemption.add"
+	SubChannelChannelPointsCustomRewardRedemptionUpdate EventSubscription = "channel.channel_points_custom_reward_redemption.update"
+
+	SubChannelChannelPointsAutomaticRewardRedemptionAdd EventSubscription = "channel.channel_points_automatic_reward_redemption.add"
+
+	SubChannelPollBegin    EventSubscription = "channel.poll.begin"
+	SubChannelPollProgress EventSubscription = "channel.poll.progress"
+	SubChannelPollEnd      EventSubscription = "channel.poll.end"
+
+	SubChannelPredictionBegin    EventSubscription = "channel.prediction.begin"
+	SubChannelPredictionProgress EventSubscription = "channel.prediction.progress"
+	SubChannelPredictionLock     EventSubscription = "channel.prediction.lock"
+	SubChannelPredictionEnd      EventSubscription = "channel.prediction.end"
+
+	SubDropEntitlementGrant EventSubscription = "drop.entitlement.grant"
+
+	SubExtensionBitsTransactionCreate EventSubscription = "extension.bits_transaction.create"
+
+	SubChannelGoalBegin    EventSubscription = "channel.goal.begin"
+	SubChannelGoalProgress EventSubscription = "channel.goal.progress"
+	SubChannelGoalEnd      EventSubscription = "channel.goal.end"
+
+	SubChannelHypeTrainBegin    EventSubscription = "channel.hype_train.begin"
+	SubChannelHypeTrainProgress EventSubscription = "channel.hype_train.progress"
+	SubChannelHypeTrainEnd      EventSubscription = "channel.hype_train.end"
+
+	SubStreamOnline  EventSubscription = "stream.online"
+	SubStreamOffline EventSubscription = "stream.offline"
+
+	SubUserAuthorizationGrant  EventSubscription = "user.authorization.grant"
+	SubUserAuthorizationRevoke EventSubscription = "user.authorization.revoke"
+
+	SubUserUpdate EventSubscription = "user.update"
+
+	SubChannelCharityCampaignDonate   EventSubscription = "channel.charity_campaign.donate"
+	SubChannelCharityCampaignStart    EventSubscription = "channel.charity_campaign.start"
+	SubChannelCharityCampaignProgress EventSubscription = "channel.charity_campaign.progress"
+	SubChannelCharityCampaignStop     EventSubscription = "channel.charity_campaign.stop"
+
+	SubChannelShieldModeBegin EventSubscription = "channel.shield_mode.begin"
+	SubChannelShieldModeEnd   EventSubscription = "channel.shield_mode.end"
+
+	SubChannelShoutoutCreate  EventSubscription = "channel.shoutout.create"
+	SubChannelShoutoutReceive EventSubscription = "channel.shoutout.receive"
+
+	SubChannelModerate EventSubscription = "channel.moderate"
+
+	SubAutomodMessageHold   EventSubscription = "automod.message.hold"
+	SubAutomodMessageUpdate EventSubscription = "automod.message.update"
+
+	SubAutomodSettingsUpdate EventSubscription = "automod.settings.update"
+
+	SubAutomodTermsUpdate EventSubscription = "automod.terms.update"
+
+	SubChannelChatUserMessageHold   EventSubscription = "channel.chat.user_message_hold"
+	SubChannelChatUserMessageUpdate EventSubscription = "channel.chat.user_message_update"
+	SubChannelChatClear             EventSubscription = "channel.chat.clear"
+	SubChannelChatClearUserMessages EventSubscription = "channel.chat.clear_user_messages"
+	SubChannelChatMessage           EventSubscription = "channel.chat.message"
+	SubChannelChatMessageDelete     EventSubscription = "channel.chat.message_delete"
+	SubChannelChatNotification      EventSubscription = "channel.chat.notification"
+
+	SubChannelChatSettingsUpdate EventSubscription = "channel.chat_settings.update"
+
+	SubChannelSuspiciousUserMessage EventSubscription = "channel.suspicious_user.message"
+	SubChannelSuspiciousUserUpdate  EventSubscription = "channel.suspicious_user.update"
+
+	SubChannelSharedChatBegin  EventSubscription = "channel.shared_chat.begin"
+	SubChannelSharedChatUpdate EventSubscription = "channel.shared_chat.update"
+	SubChannelSharedChatEnd    EventSubscription = "channel.shared_chat.end"
+
+	SubUserWhisperMessage EventSubscription = "user.whisper.message"
+
+	SubChannelAdBreakBegin EventSubscription = "channel.ad_break.begin"
+
+	SubChannelWarningAcknowledge EventSubscription = "channel.warning.acknowledge"
+	SubChannelWarningSend        EventSubscription = "channel.warning.send"
+
+	SubChannelUnbanRequestCreate  EventSubscription = "channel.unban_request.create"
+	SubChannelUnbanRequestResolve EventSubscription = "channel.unban_request.resolve"
+
+	SubConduitShardDisabled EventSubscription = "conduit.shard.disabled"
+
+	subMetadata = map[EventSubscription]subscriptionMetadata{
+		SubChannelUpdate: {
+			Version:            "2",
+			EventGen:           zeroPtrGen[EventChannelUpdate](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Transports:         userTransports,
+			Cost:               CostUnauthorized,
+		},
+		SubChannelFollow: {
+			Version:            "2",
+			EventGen:           zeroPtrGen[EventChannelFollow](),
+			RequiredConditions: []string{"broadcaster_user_id", "moderator_user_id"},
+			Scopes:             [][]string{{"moderator:read:followers"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelSubscribe: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelSubscribe](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:subscriptions"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelSubscriptionEnd: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelSubscriptionEnd](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:subscriptions"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelSubscriptionGift: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelSubscriptionGift](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:subscriptions"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelSubscriptionMessage: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelSubscriptionMessage](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:subscriptions"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelCheer: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelCheer](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"bits:read"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelRaid: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelRaid](),
+			OptionalConditions: []string{"from_broadcaster_user_id", "to_broadcaster_user_id"},
+			OneOfConditions:    []string{"from_broadcaster_user_id", "to_broadcaster_user_id"},
+			Transports:         userTransports,
+			Cost:               CostUnauthorized,
+		},
+		SubChannelBan: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelBan](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:moderate"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelUnban: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelUnban](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:moderate"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelModeratorAdd: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelModeratorAdd](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"moderation:read"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelModeratorRemove: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelModeratorRemove](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"moderation:read"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelVIPAdd: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelVIPAdd](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:vips", "channel:manage:vips"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelVIPRemove: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelVIPRemove](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:vips", "channel:manage:vips"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelChannelPointsCustomRewardAdd: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelChannelPointsCustomRewardAdd](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:redemptions", "channel:manage:redemptions"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelChannelPointsCustomRewardUpdate: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelChannelPointsCustomRewardUpdate](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			OptionalConditions: []string{"reward_id"},
+			Scopes:             [][]string{{"channel:read:redemptions", "channel:manage:redemptions"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelChannelPointsCustomRewardRemove: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelChannelPointsCustomRewardRemove](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			OptionalConditions: []string{"reward_id"},
+			Scopes:             [][]string{{"channel:read:redemptions", "channel:manage:redemptions"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelChannelPointsCustomRewardRedemptionAdd: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelChannelPointsCustomRewardRedemptionAdd](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			OptionalConditions: []string{"reward_id"},
+			Scopes:             [][]string{{"channel:read:redemptions", "channel:manage:redemptions"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelChannelPointsCustomRewardRedemptionUpdate: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelChannelPointsCustomRewardRedemptionUpdate](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			OptionalConditions: []string{"reward_id"},
+			Scopes:             [][]string{{"channel:read:redemptions", "channel:manage:redemptions"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelChannelPointsAutomaticRewardRedemptionAdd: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelChannelPointsAutomaticRewardRedemptionAdd](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:redemptions", "channel:manage:redemptions"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelPollBegin: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelPollBegin](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:polls", "channel:manage:polls"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelPollProgress: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelPollProgress](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:polls", "channel:manage:polls"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelPollEnd: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelPollEnd](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:polls", "channel:manage:polls"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelPredictionBegin: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelPredictionBegin](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:predictions", "channel:manage:predictions"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelPredictionProgress: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelPredictionProgress](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:predictions", "channel:manage:predictions"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelPredictionLock: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelPredictionLock](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:predictions", "channel:manage:predictions"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelPredictionEnd: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelPredictionEnd](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:predictions", "channel:manage:predictions"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubDropEntitlementGrant: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[[]EventDropEntitlementGrant](),
+			RequiredConditions: []string{"organization_id"},
+			OptionalConditions: []string{"category_id", "campaign_id"},
+			Transports:         appTransports,
+			Cost:               CostUnauthorized,
+		},
+		SubExtensionBitsTransactionCreate: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventExtensionBitsTransactionCreate](),
+			RequiredConditions: []string{"extension_client_id"},
+			Transports:         appTransports,
+			Cost:               CostUnauthorized,
+		},
+		SubChannelGoalBegin: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelGoalBegin](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:goals"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelGoalProgress: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelGoalProgress](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:goals"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelGoalEnd: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelGoalEnd](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:goals"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelHypeTrainBegin: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelHypeTrainBegin](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:hype_train"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelHypeTrainProgress: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelHypeTrainProgress](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:hype_train"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelHypeTrainEnd: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelHypeTrainEnd](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:hype_train"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubStreamOnline: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventStreamOnline](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Transports:         userTransports,
+			Cost:               CostUnauthorized,
+		},
+		SubStreamOffline: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventStreamOffline](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Transports:         userTransports,
+			Cost:               CostUnauthorized,
+		},
+		SubUserAuthorizationGrant: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventUserAuthorizationGrant](),
+			RequiredConditions: []string{"client_id"},
+			Transports:         appTransports,
+			Cost:               CostUnauthorized,
+		},
+		SubUserAuthorizationRevoke: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventUserAuthorizationRevoke](),
+			RequiredConditions: []string{"client_id"},
+			Transports:         appTransports,
+			Cost:               CostUnauthorized,
+		},
+		SubUserUpdate: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventUserUpdate](),
+			RequiredConditions: []string{"user_id"},
+			Transports:         userTransports,
+			Cost:               CostUnauthorized,
+		},
+		SubChannelCharityCampaignDonate: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelCharityCampaignDonate](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:charity"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelCharityCampaignStart: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelCharityCampaignStart](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:charity"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelCharityCampaignProgress: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelCharityCampaignProgress](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:charity"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelCharityCampaignStop: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelCharityCampaignStop](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:charity"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelShieldModeBegin: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelShieldModeBegin](),
+			RequiredConditions: []string{"broadcaster_user_id", "moderator_user_id"},
+			Scopes:             [][]string{{"moderator:read:shield_mode", "moderator:manage:shield_mode"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelShieldModeEnd: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelShieldModeEnd](),
+			RequiredConditions: []string{"broadcaster_user_id", "moderator_user_id"},
+			Scopes:             [][]string{{"moderator:read:shield_mode", "moderator:manage:shield_mode"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelShoutoutCreate: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelShoutoutCreate](),
+			RequiredConditions: []string{"broadcaster_user_id", "moderator_user_id"},
+			Scopes:             [][]string{{"moderator:read:shoutouts", "moderator:manage:shoutouts"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelShoutoutReceive: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelShoutoutReceive](),
+			RequiredConditions: []string{"broadcaster_user_id", "moderator_user_id"},
+			Scopes:             [][]string{{"moderator:read:shoutouts", "moderator:manage:shoutouts"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelModerate: {
+			Version:            "2",
+			EventGen:           zeroPtrGen[EventChannelModerate](),
+			RequiredConditions: []string{"broadcaster_user_id", "moderator_user_id"},
+			Scopes: [][]string{
+				{"moderator:read:blocked_terms", "moderator:manage:blocked_terms"},
+				{"moderator:read:chat_settings", "moderator:manage:chat_settings"},
+				{"moderator:read:unban_requests", "moderator:manage:unban_requests"},
+				{"moderator:read:banned_users", "moderator:manage:banned_users"},
+				{"moderator:read:chat_messages", "moderator:manage:chat_messages"},
+				{"moderator:read:warnings", "moderator:manage:warnings"},
+				{"moderator:read:moderators"},
+				{"moderator:read:vips"},
+			},
+			Transports: userTransports,
+			Cost:       CostAuthorized,
+		},
+		SubAutomodMessageHold: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventAutomodMessageHold](),
+			RequiredConditions: []string{"broadcaster_user_id", "moderator_user_id"},
+			Scopes:             [][]string{{"moderator:manage:automod"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubAutomodMessageUpdate: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventAutomodMessageUpdate](),
+			RequiredConditions: []string{"broadcaster_user_id", "moderator_user_id"},
+			Scopes:             [][]string{{"moderator:manage:automod"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubAutomodSettingsUpdate: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventAutomodSettingsUpdate](),
+			RequiredConditions: []string{"broadcaster_user_id", "moderator_user_id"},
+			Scopes:             [][]string{{"moderator:read:automod_settings"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubAutomodTermsUpdate: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventAutomodTermsUpdate](),
+			RequiredConditions: []string{"broadcaster_user_id", "moderator_user_id"},
+			Scopes:             [][]string{{"moderator:manage:automod"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelChatUserMessageHold: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelChatUserMessageHold](),
+			RequiredConditions: []string{"broadcaster_user_id", "user_id"},
+			Scopes:             [][]string{{"user:read:chat"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelChatUserMessageUpdate: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelChatUserMessageUpdate](),
+			RequiredConditions: []string{"broadcaster_user_id", "user_id"},
+			Scopes:             [][]string{{"user:read:chat"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelChatClear: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelChatClear](),
+			RequiredConditions: []string{"broadcaster_user_id", "user_id"},
+			Scopes:             [][]string{{"user:read:chat"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelChatClearUserMessages: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelChatClearUserMessages](),
+			RequiredConditions: []string{"broadcaster_user_id", "user_id"},
+			Scopes:             [][]string{{"user:read:chat"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelChatMessage: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelChatMessage](),
+			RequiredConditions: []string{"broadcaster_user_id", "user_id"},
+			Scopes:             [][]string{{"user:read:chat"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelChatMessageDelete: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelChatMessageDelete](),
+			RequiredConditions: []string{"broadcaster_user_id", "user_id"},
+			Scopes:             [][]string{{"user:read:chat"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelChatNotification: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelChatNotification](),
+			RequiredConditions: []string{"broadcaster_user_id", "user_id"},
+			Scopes:             [][]string{{"user:read:chat"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelChatSettingsUpdate: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelChatSettingsUpdate](),
+			RequiredConditions: []string{"broadcaster_user_id", "user_id"},
+			Scopes:             [][]string{{"user:read:chat"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelSuspiciousUserMessage: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelSuspiciousUserMessage](),
+			RequiredConditions: []string{"broadcaster_user_id", "moderator_user_id"},
+			Scopes:             [][]string{{"moderator:read:suspicious_users"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelSuspiciousUserUpdate: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelSuspiciousUserUpdate](),
+			RequiredConditions: []string{"broadcaster_user_id", "moderator_user_id"},
+			Scopes:             [][]string{{"moderator:read:suspicious_users"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelSharedChatBegin: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelSharedChatBegin](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Transports:         userTransports,
+			Cost:               CostUnauthorized,
+		},
+		SubChannelSharedChatUpdate: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelSharedChatUpdate](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Transports:         userTransports,
+			Cost:               CostUnauthorized,
+		},
+		SubChannelSharedChatEnd: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelSharedChatEnd](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Transports:         userTransports,
+			Cost:               CostUnauthorized,
+		},
+		SubUserWhisperMessage: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventUserWhisperMessage](),
+			RequiredConditions: []string{"user_id"},
+			Scopes:             [][]string{{"user:read:whispers", "user:manage:whispers"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelAdBreakBegin: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelAdBreakBegin](),
+			RequiredConditions: []string{"broadcaster_user_id"},
+			Scopes:             [][]string{{"channel:read:ads"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelWarningAcknowledge: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelWarningAcknowledge](),
+			RequiredConditions: []string{"broadcaster_user_id", "moderator_user_id"},
+			Scopes:             [][]string{{"moderator:read:warnings", "moderator:manage:warnings"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelWarningSend: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelWarningSend](),
+			RequiredConditions: []string{"broadcaster_user_id", "moderator_user_id"},
+			Scopes:             [][]string{{"moderator:read:warnings", "moderator:manage:warnings"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelUnbanRequestCreate: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelUnbanRequestCreate](),
+			RequiredConditions: []string{"broadcaster_user_id", "moderator_user_id"},
+			Scopes:             [][]string{{"moderator:read:unban_requests", "moderator:manage:unban_requests"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubChannelUnbanRequestResolve: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventChannelUnbanRequestResolve](),
+			RequiredConditions: []string{"broadcaster_user_id", "moderator_user_id"},
+			Scopes:             [][]string{{"moderator:read:unban_requests", "moderator:manage:unban_requests"}},
+			Transports:         userTransports,
+			Cost:               CostAuthorized,
+		},
+		SubConduitShardDisabled: {
+			Version:            "1",
+			EventGen:           zeroPtrGen[EventConduitShardDisabled](),
+			RequiredConditions: []string{"client_id"},
+			OptionalConditions: []string{"conduit_id"},
+			Transports:         appTransports,
+			Cost:               CostUnauthorized,
+		},
+	}
+)
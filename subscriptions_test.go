@@ -6,9 +6,11 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestEventVersion(t *testing.T) {
@@ -53,10 +55,253 @@ func TestEventVersion(t *testing.T) {
 				go http.Serve(listener, mux)
 
 				twitch.SubscribeEventUrl(twitch.SubscribeRequest{
+					SessionID:       "session",
 					Event:           twitch.SubChannelUpdate,
 					VersionOverride: tc.Version,
+					Condition:       map[string]string{"broadcaster_user_id": "12345"},
 				}, fmt.Sprintf("http://%s", listener.Addr().String()))
 			})
 		})
 	}
 }
+
+func TestSubscriptionInfo(t *testing.T) {
+	t.Parallel()
+
+	chat, ok := twitch.SubscriptionInfo(twitch.SubChannelChatMessage)
+	assert.True(t, ok)
+	assert.Equal(t, "1", chat.Version)
+	assert.Equal(t, []string{"broadcaster_user_id", "user_id"}, chat.RequiredConditions)
+	assert.Equal(t, [][]string{{"user:read:chat"}}, chat.Scopes)
+	assert.True(t, chat.SupportsTransport(twitch.TransportWebsocket))
+
+	raid, ok := twitch.SubscriptionInfo(twitch.SubChannelRaid)
+	assert.True(t, ok)
+	assert.Empty(t, raid.RequiredConditions)
+	assert.Equal(t, []string{"from_broadcaster_user_id", "to_broadcaster_user_id"}, raid.OneOfConditions)
+	assert.Equal(t, twitch.CostUnauthorized, raid.Cost)
+
+	grant, ok := twitch.SubscriptionInfo(twitch.SubUserAuthorizationGrant)
+	assert.True(t, ok)
+	assert.False(t, grant.SupportsTransport(twitch.TransportWebsocket))
+
+	_, ok = twitch.SubscriptionInfo("unknown")
+	assert.False(t, ok)
+}
+
+func TestSubscriptionTypes(t *testing.T) {
+	t.Parallel()
+
+	for _, event := range twitch.SubscriptionTypes() {
+		details, ok := twitch.SubscriptionInfo(event)
+		assert.True(t, ok, event)
+		assert.NotEmpty(t, details.Version, event)
+		assert.NotEmpty(t, details.Transports, event)
+		assert.False(t, len(details.RequiredConditions) == 0 && len(details.OneOfConditions) == 0, "%s has no conditions", event)
+	}
+}
+
+func TestTypedCondition(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name      string
+		Event     twitch.EventSubscription
+		Condition twitch.Condition
+		Valid     bool
+	}{
+		{"Broadcaster", twitch.SubStreamOnline, twitch.BroadcasterCondition{BroadcasterUserID: "1"}, true},
+		{"Moderator", twitch.SubChannelFollow, twitch.ModeratorCondition{BroadcasterUserID: "1", ModeratorUserID: "2"}, true},
+		{"MissingModerator", twitch.SubChannelFollow, twitch.ModeratorCondition{BroadcasterUserID: "1"}, false},
+		{"RaidTo", twitch.SubChannelRaid, twitch.RaidCondition{ToBroadcasterUserID: "1"}, true},
+		{"RaidBoth", twitch.SubChannelRaid, twitch.RaidCondition{FromBroadcasterUserID: "1", ToBroadcasterUserID: "2"}, false},
+		{"Chat", twitch.SubChannelChatMessage, twitch.ChatCondition{BroadcasterUserID: "1", UserID: "2"}, true},
+		{"ChatWrongType", twitch.SubChannelChatMessage, twitch.BroadcasterCondition{BroadcasterUserID: "1"}, false},
+		{"Reward", twitch.SubChannelChannelPointsCustomRewardRedemptionAdd, twitch.RewardCondition{BroadcasterUserID: "1", RewardID: "abc"}, true},
+		{"Map", twitch.SubChannelUpdate, twitch.ConditionMap{"broadcaster_id": "1"}, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var called bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+
+				var subscription twitch.SubscriptionRequest
+				err := json.NewDecoder(r.Body).Decode(&subscription)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.Condition.ConditionMap(), subscription.Condition)
+
+				w.WriteHeader(http.StatusAccepted)
+				w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			_, err := twitch.SubscribeEventUrl(twitch.SubscribeRequest{
+				SessionID:      "session",
+				Event:          tc.Event,
+				TypedCondition: tc.Condition,
+			}, server.URL)
+
+			if tc.Valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+			assert.Equal(t, tc.Valid, called)
+		})
+	}
+}
+
+func TestConditionMap(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, map[string]string{"client_id": "1"}, twitch.ConduitCondition{ClientID: "1"}.ConditionMap())
+	assert.Equal(t, map[string]string{"client_id": "1"}, twitch.ClientCondition{ClientID: "1"}.ConditionMap())
+	assert.Equal(t, map[string]string{"organization_id": "1", "campaign_id": "2"}, twitch.DropEntitlementCondition{OrganizationID: "1", CampaignID: "2"}.ConditionMap())
+}
+
+func TestSubscribeRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name    string
+		Request twitch.SubscribeRequest
+		Errors  int
+	}{
+		{"Valid", twitch.SubscribeRequest{
+			SessionID: "session",
+			Event:     twitch.SubChannelChatMessage,
+			Condition: map[string]string{"broadcaster_user_id": "1", "user_id": "2"},
+		}, 0},
+		{"UnknownWithVersion", twitch.SubscribeRequest{
+			SessionID:       "session",
+			Event:           "channel.new_thing",
+			VersionOverride: "beta",
+		}, 0},
+		{"Empty", twitch.SubscribeRequest{}, 2},
+		{"UnknownWithoutVersion", twitch.SubscribeRequest{
+			SessionID: "session",
+			Event:     "channel.new_thing",
+		}, 1},
+		{"WebhookOnly", twitch.SubscribeRequest{
+			SessionID:      "session",
+			Event:          twitch.SubUserAuthorizationGrant,
+			TypedCondition: twitch.ClientCondition{ClientID: "client"},
+		}, 1},
+		{"EveryProblem", twitch.SubscribeRequest{
+			Event:     twitch.SubChannelChatMessage,
+			Condition: map[string]string{"broadcaster_user_id": "joeyak", "broadcaster_id": "1"},
+		}, 4},
+		{"Webhook", twitch.SubscribeRequest{
+			Transport:         twitch.TransportWebhook,
+			Callback:          "https://example.com/eventsub",
+			Secret:            "0123456789abcdef",
+			DeclaredTokenType: twitch.TokenApp,
+			Event:             twitch.SubUserAuthorizationGrant,
+			TypedCondition:    twitch.ClientCondition{ClientID: "client"},
+		}, 0},
+		{"WebhookProblems", twitch.SubscribeRequest{
+			Transport:         twitch.TransportWebhook,
+			Callback:          "http://example.com/eventsub",
+			Secret:            "short",
+			DeclaredTokenType: twitch.TokenUser,
+			Event:             twitch.SubStreamOnline,
+			TypedCondition:    twitch.BroadcasterCondition{BroadcasterUserID: "1"},
+		}, 3},
+		{"WebhookUndeclaredToken", twitch.SubscribeRequest{
+			Transport:      twitch.TransportWebhook,
+			Callback:       "https://example.com/eventsub",
+			Secret:         "0123456789abcdef",
+			AccessToken:    "user",
+			Event:          twitch.SubStreamOnline,
+			TypedCondition: twitch.BroadcasterCondition{BroadcasterUserID: "1"},
+		}, 0},
+		{"WebsocketAppToken", twitch.SubscribeRequest{
+			SessionID:         "session",
+			DeclaredTokenType: twitch.TokenApp,
+			Event:             twitch.SubStreamOnline,
+			TypedCondition:    twitch.BroadcasterCondition{BroadcasterUserID: "1"},
+		}, 1},
+		{"BothConditions", twitch.SubscribeRequest{
+			SessionID:      "session",
+			Event:          twitch.SubStreamOnline,
+			Condition:      map[string]string{"broadcaster_user_id": "1"},
+			TypedCondition: twitch.BroadcasterCondition{BroadcasterUserID: "1"},
+		}, 1},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.Request.Validate()
+			if tc.Errors == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErr *twitch.ValidationError
+			if assert.ErrorAs(t, err, &validationErr) {
+				assert.Len(t, validationErr.Errors, tc.Errors, validationErr.Error())
+			}
+		})
+	}
+}
+
+func TestSubscribeRequestValidateErrors(t *testing.T) {
+	t.Parallel()
+
+	err := twitch.SubscribeRequest{
+		SessionID: "session",
+		Event:     twitch.SubChannelChatMessage,
+		Condition: map[string]string{"broadcaster_user_id": "1"},
+	}.Validate()
+	assert.ErrorIs(t, err, twitch.ErrMissingCondition)
+	assert.NotErrorIs(t, err, twitch.ErrInvalidCondition)
+
+	err = twitch.SubscribeRequest{
+		SessionID: "session",
+		Event:     twitch.SubChannelChatMessage,
+		Condition: map[string]string{"broadcaster_user_id": "joeyak", "user_id": "2"},
+	}.Validate()
+	assert.ErrorIs(t, err, twitch.ErrInvalidCondition)
+	assert.NotErrorIs(t, err, twitch.ErrMissingCondition)
+
+	err = twitch.SubscribeRequest{
+		SessionID: "session",
+		Event:     twitch.SubChannelChannelPointsCustomRewardUpdate,
+		Condition: map[string]string{"broadcaster_user_id": "1", "reward_id": "92af127c-7326-4483-a52b-b0da0be61c01"},
+	}.Validate()
+	assert.NoError(t, err, "only user id conditions are numeric")
+}
+
+func TestConditionKinds(t *testing.T) {
+	t.Parallel()
+
+	for _, event := range twitch.SubscriptionTypes() {
+		details, _ := twitch.SubscriptionInfo(event)
+		keys := append(append(details.RequiredConditions, details.OptionalConditions...), details.OneOfConditions...)
+		for _, key := range keys {
+			assert.True(t, twitch.HasConditionKind(key), "condition %s of %s has no kind", key, event)
+		}
+	}
+}
+
+func TestSubscribeValidatesBeforeRequest(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not have been sent")
+	}))
+	defer server.Close()
+
+	_, err := twitch.SubscribeEventUrl(twitch.SubscribeRequest{Event: twitch.SubStreamOnline}, server.URL)
+
+	var validationErr *twitch.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
@@ -0,0 +1,67 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const twitchValidateUrl = "https://id.twitch.tv/oauth2/validate"
+
+// TokenType is the kind of access token used for a request.
+type TokenType string
+
+const (
+	TokenUser TokenType = "user"
+	TokenApp  TokenType = "app"
+)
+
+// TokenInfo is what twitch reports about an access token.
+type TokenInfo struct {
+	ClientID  string   `json:"client_id"`
+	Login     string   `json:"login"`
+	UserID    string   `json:"user_id"`
+	Scopes    []string `json:"scopes"`
+	ExpiresIn int      `json:"expires_in"`
+}
+
+// Type is TokenApp for app access tokens since they do not belong to a user.
+func (i TokenInfo) Type() TokenType {
+	if i.UserID == "" {
+		return TokenApp
+	}
+	return TokenUser
+}
+
+// ValidateToken asks twitch what the access token is, which can be used to fill in the DeclaredTokenType of requests.
+func (c *SubscriptionClient) ValidateToken(ctx context.Context, accessToken string) (TokenInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.ValidateUrl, nil)
+	if err != nil {
+		return TokenInfo{}, fmt.Errorf("could not create new request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("OAuth %s", accessToken))
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return TokenInfo{}, fmt.Errorf("could not validate token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return TokenInfo{}, fmt.Errorf("could not validate token: %w", &ResponseError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Body:       string(data),
+		})
+	}
+
+	var info TokenInfo
+	err = json.Unmarshal(data, &info)
+	if err != nil {
+		return TokenInfo{}, fmt.Errorf("could not unmarshal token info: %w", err)
+	}
+	return info, nil
+}
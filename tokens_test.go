@@ -0,0 +1,45 @@
+package twitch_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateToken(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "OAuth user":
+			w.Write([]byte(`{"client_id": "client", "login": "joeyak", "user_id": "1", "scopes": ["user:read:chat"], "expires_in": 3600}`))
+		case "OAuth app":
+			w.Write([]byte(`{"client_id": "client", "scopes": [], "expires_in": 3600}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	client := twitch.NewSubscriptionClient()
+	client.ValidateUrl = server.URL
+
+	info, err := client.ValidateToken(context.Background(), "user")
+	assert.NoError(t, err)
+	assert.Equal(t, twitch.TokenUser, info.Type())
+	assert.Equal(t, []string{"user:read:chat"}, info.Scopes)
+
+	info, err = client.ValidateToken(context.Background(), "app")
+	assert.NoError(t, err)
+	assert.Equal(t, twitch.TokenApp, info.Type())
+
+	_, err = client.ValidateToken(context.Background(), "invalid")
+	var respErr *twitch.ResponseError
+	if assert.ErrorAs(t, err, &respErr) {
+		assert.Equal(t, http.StatusUnauthorized, respErr.StatusCode)
+	}
+}
@@ -21,7 +21,10 @@ type PayloadSession struct {
 
 type SubscriptionTransport struct {
 	Method    string `json:"method"`
-	SessionID string `json:"session_id"`
+	SessionID string `json:"session_id,omitempty"`
+	ConduitID string `json:"conduit_id,omitempty"`
+	Callback  string `json:"callback,omitempty"`
+	Secret    string `json:"secret,omitempty"`
 }
 
 type SubscriptionRequest struct {
@@ -73,3 +76,17 @@ type RevokeMessage struct {
 		Subscription PayloadSubscription `json:"subscription"`
 	} `json:"payload"`
 }
+
+type RevocationReason string
+
+const (
+	RevocationAuthorizationRevoked         RevocationReason = "authorization_revoked"
+	RevocationUserRemoved                  RevocationReason = "user_removed"
+	RevocationVersionRemoved               RevocationReason = "version_removed"
+	RevocationNotificationFailuresExceeded RevocationReason = "notification_failures_exceeded"
+)
+
+// Reason returns why twitch revoked the subscription.
+func (m RevokeMessage) Reason() RevocationReason {
+	return RevocationReason(m.Payload.Subscription.Status)
+}
@@ -0,0 +1,283 @@
+package twitch
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	webhookMaxMessageAge  = 10 * time.Minute
+	webhookMaxClockSkew   = time.Minute
+	webhookMaxBodySize    = 1 << 20
+	webhookRecentMessages = 1000
+
+	minWebhookSecretLength = 10
+	maxWebhookSecretLength = 100
+
+	headerMessageID        = "Twitch-Eventsub-Message-Id"
+	headerMessageType      = "Twitch-Eventsub-Message-Type"
+	headerMessageSignature = "Twitch-Eventsub-Message-Signature"
+	headerMessageTimestamp = "Twitch-Eventsub-Message-Timestamp"
+
+	webhookNotification = "notification"
+	webhookVerification = "webhook_callback_verification"
+	webhookRevocation   = "revocation"
+)
+
+var (
+	ErrInvalidSignature = fmt.Errorf("invalid webhook signature")
+	ErrStaleMessage     = fmt.Errorf("webhook message is too old")
+	ErrFutureMessage    = fmt.Errorf("webhook message is from the future")
+)
+
+// WebhookHandler receives events sent to a webhook callback.
+// Every request is verified with the HMAC-SHA256 signature of the message id, timestamp and body
+// before it is handled. Callback verification challenges are answered, and notifications are
+// dispatched through the same handlers as a Client. Twitch retries deliveries, so notifications
+// and revocations with the id of a recent message are acknowledged without being handled again.
+type WebhookHandler struct {
+	*handlers
+
+	// Secret is used to verify messages when SecretLookup is nil or has no secret for the subscription.
+	Secret string
+	// SecretLookup finds the secret of the subscription a message is for.
+	SecretLookup func(subscription PayloadSubscription) (string, bool)
+	// Clock is used to reject messages older than 10 minutes or sent more than a minute from now.
+	Clock Clock
+
+	onVerification func(subscription PayloadSubscription)
+	recent         recentMessages
+}
+
+func NewWebhookHandler(secret string) *WebhookHandler {
+	return &WebhookHandler{
+		handlers: newHandlers(),
+		Secret:   secret,
+		Clock:    SystemClock,
+	}
+}
+
+// OnVerification is called when twitch verifies the callback of a new subscription.
+func (h *WebhookHandler) OnVerification(callback func(subscription PayloadSubscription)) {
+	h.onVerification = callback
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, webhookMaxBodySize))
+	if err != nil {
+		h.onError(fmt.Errorf("could not read webhook body: %w", err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	metadata := MessageMetadata{
+		MessageID:   r.Header.Get(headerMessageID),
+		MessageType: r.Header.Get(headerMessageType),
+	}
+	metadata.MessageTimestamp, _ = time.Parse(time.RFC3339Nano, r.Header.Get(headerMessageTimestamp))
+
+	// Only the subscription is needed to find the secret, the body is not decoded before it is verified
+	var signed struct {
+		Subscription PayloadSubscription `json:"subscription"`
+	}
+	json.Unmarshal(body, &signed)
+
+	err = h.verify(r.Header, body, h.secret(signed.Subscription))
+	if err != nil {
+		h.onError(err)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	var payload struct {
+		Subscription PayloadSubscription `json:"subscription"`
+		Event        *json.RawMessage    `json:"event"`
+		Challenge    string              `json:"challenge"`
+	}
+	err = decodeJSON(body, &payload)
+	if err != nil {
+		h.onError(fmt.Errorf("could not unmarshal webhook %s: %w", metadata.MessageType, err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if metadata.MessageType != webhookVerification && !h.recent.add(metadata.MessageID) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	switch metadata.MessageType {
+	case webhookVerification:
+		callFunc(h.onVerification, payload.Subscription)
+
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, payload.Challenge)
+	case webhookNotification:
+		var message NotificationMessage
+		message.Metadata = metadata
+		message.Payload.Subscription = payload.Subscription
+		message.Payload.Event = payload.Event
+		callFunc(h.onNotification, message)
+
+		err = h.handleNotification(message)
+		if err != nil {
+			h.onError(fmt.Errorf("could not handle notification: %w", err))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case webhookRevocation:
+		var message RevokeMessage
+		message.Metadata = metadata
+		message.Payload.Subscription = payload.Subscription
+		callFunc(h.onRevoke, message)
+
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		h.onError(fmt.Errorf("unknown webhook message type %s", metadata.MessageType))
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func (h *WebhookHandler) secret(subscription PayloadSubscription) string {
+	if h.SecretLookup != nil {
+		if secret, ok := h.SecretLookup(subscription); ok {
+			return secret
+		}
+	}
+	return h.Secret
+}
+
+func (h *WebhookHandler) verify(header http.Header, body []byte, secret string) error {
+	timestamp := header.Get(headerMessageTimestamp)
+	sentAt, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return fmt.Errorf("could not parse webhook timestamp %q: %w", timestamp, err)
+	}
+	age := orSystemClock(h.Clock).Now().Sub(sentAt)
+	if age > webhookMaxMessageAge {
+		return fmt.Errorf("%w: sent at %s", ErrStaleMessage, timestamp)
+	}
+	if age < -webhookMaxClockSkew {
+		return fmt.Errorf("%w: sent at %s", ErrFutureMessage, timestamp)
+	}
+
+	expected := WebhookSignature(secret, header.Get(headerMessageID), timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(header.Get(headerMessageSignature)))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// recentMessages remembers the ids of the last webhook messages.
+type recentMessages struct {
+	mu    sync.Mutex
+	ids   map[string]bool
+	order []string
+}
+
+// add reports false when the id was already added, the oldest id is forgotten once the set is full.
+func (m *recentMessages) add(id string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.ids == nil {
+		m.ids = map[string]bool{}
+	}
+	if m.ids[id] {
+		return false
+	}
+
+	if len(m.order) >= webhookRecentMessages {
+		delete(m.ids, m.order[0])
+		m.order = m.order[1:]
+	}
+	m.ids[id] = true
+	m.order = append(m.order, id)
+	return true
+}
+
+// WebhookSignature is the value of the signature header twitch sends for a message.
+func WebhookSignature(secret, messageID, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(messageID))
+	mac.Write([]byte(timestamp))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// GenerateWebhookSecret returns a random secret for a webhook subscription.
+func GenerateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("could not generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// WebhookSecrets keeps a secret per webhook subscription. Secrets are stored by event type and condition
+// since the callback verification arrives before the subscription id is known.
+// Its Lookup can be used as the SecretLookup of a WebhookHandler.
+type WebhookSecrets struct {
+	mu      sync.Mutex
+	secrets map[string]string
+}
+
+func NewWebhookSecrets() *WebhookSecrets {
+	return &WebhookSecrets{
+		secrets: map[string]string{},
+	}
+}
+
+// Assign generates a secret for the request if it does not have one and stores it.
+func (s *WebhookSecrets) Assign(request SubscribeRequest) (SubscribeRequest, error) {
+	condition, err := request.condition()
+	if err != nil {
+		return request, err
+	}
+
+	if request.Secret == "" {
+		request.Secret, err = GenerateWebhookSecret()
+		if err != nil {
+			return request, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.secrets[webhookSecretKey(request.Event, condition)] = request.Secret
+	return request, nil
+}
+
+// Remove forgets the secret of a subscription.
+func (s *WebhookSecrets) Remove(subscription PayloadSubscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.secrets, webhookSecretKey(subscription.Type, subscription.Condition))
+}
+
+func (s *WebhookSecrets) Lookup(subscription PayloadSubscription) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	secret, ok := s.secrets[webhookSecretKey(subscription.Type, subscription.Condition)]
+	return secret, ok
+}
+
+func webhookSecretKey(event EventSubscription, condition map[string]string) string {
+	return fmt.Sprintf("%s|%s", event, conditionKey(condition))
+}
@@ -0,0 +1,186 @@
+package twitch_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+const testWebhookSecret = "0123456789abcdef"
+
+func newWebhookRequest(t *testing.T, secret, messageType string, sentAt time.Time, body string) *http.Request {
+	request := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewBufferString(body))
+
+	timestamp := sentAt.UTC().Format(time.RFC3339Nano)
+	request.Header.Set("Twitch-Eventsub-Message-Id", "message-id")
+	request.Header.Set("Twitch-Eventsub-Message-Type", messageType)
+	request.Header.Set("Twitch-Eventsub-Message-Timestamp", timestamp)
+	request.Header.Set("Twitch-Eventsub-Message-Signature", twitch.WebhookSignature(secret, "message-id", timestamp, []byte(body)))
+	return request
+}
+
+func serveWebhook(handler http.Handler, request *http.Request) (int, string) {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	body, _ := io.ReadAll(recorder.Result().Body)
+	return recorder.Code, string(body)
+}
+
+func TestWebhookVerification(t *testing.T) {
+	t.Parallel()
+
+	handler := twitch.NewWebhookHandler(testWebhookSecret)
+	handler.OnError(func(err error) {})
+
+	body := `{"challenge": "pogchamp-kappa-360noscope-vohiyo", "subscription": {"id": "sub", "type": "stream.online", "version": "1"}}`
+	code, response := serveWebhook(handler, newWebhookRequest(t, testWebhookSecret, "webhook_callback_verification", time.Now(), body))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "pogchamp-kappa-360noscope-vohiyo", response)
+
+	code, _ = serveWebhook(handler, newWebhookRequest(t, "wrong secret", "webhook_callback_verification", time.Now(), body))
+	assert.Equal(t, http.StatusForbidden, code)
+
+	code, _ = serveWebhook(handler, newWebhookRequest(t, testWebhookSecret, "webhook_callback_verification", time.Now().Add(-time.Hour), body))
+	assert.Equal(t, http.StatusForbidden, code, "old messages should be refused")
+
+	code, _ = serveWebhook(handler, newWebhookRequest(t, testWebhookSecret, "webhook_callback_verification", time.Now().Add(time.Hour), body))
+	assert.Equal(t, http.StatusForbidden, code, "messages from the future should be refused")
+}
+
+func TestWebhookVerifiesBeforeDecoding(t *testing.T) {
+	t.Parallel()
+
+	handler := twitch.NewWebhookHandler(testWebhookSecret)
+	handler.SetDecodingMode(twitch.DecodeStrict)
+
+	var errs []error
+	handler.OnError(func(err error) {
+		errs = append(errs, err)
+	})
+
+	body := `{"subscription": {"id": "sub", "type": "stream.online", "version": "1", "cost": "free"}, "unknown": true}`
+	code, _ := serveWebhook(handler, newWebhookRequest(t, "wrong secret", "notification", time.Now(), body))
+	assert.Equal(t, http.StatusForbidden, code)
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], twitch.ErrInvalidSignature)
+	}
+
+	code, _ = serveWebhook(handler, newWebhookRequest(t, testWebhookSecret, "notification", time.Now().Add(time.Hour), body))
+	assert.Equal(t, http.StatusForbidden, code)
+	if assert.Len(t, errs, 2) {
+		assert.ErrorIs(t, errs[1], twitch.ErrFutureMessage)
+	}
+}
+
+func TestWebhookNotification(t *testing.T) {
+	t.Parallel()
+
+	handler := twitch.NewWebhookHandler(testWebhookSecret)
+
+	events := make(chan twitch.EventStreamOnline, 1)
+	handler.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		events <- event
+	})
+
+	body := `{
+		"subscription": {"id": "sub", "type": "stream.online", "version": "1", "condition": {"broadcaster_user_id": "1337"}},
+		"event": {"id": "9001", "broadcaster_user_id": "1337", "broadcaster_user_login": "cool_user", "broadcaster_user_name": "Cool_User", "type": "live", "started_at": "2020-10-11T10:11:12.123Z"}
+	}`
+	code, _ := serveWebhook(handler, newWebhookRequest(t, testWebhookSecret, "notification", time.Now(), body))
+	assert.Equal(t, http.StatusNoContent, code)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, "1337", event.BroadcasterUserId)
+	case <-time.After(time.Second):
+		t.Fatal("notification was not dispatched")
+	}
+
+	code, _ = serveWebhook(handler, newWebhookRequest(t, testWebhookSecret, "notification", time.Now(), body))
+	assert.Equal(t, http.StatusNoContent, code, "retried deliveries are acknowledged")
+
+	select {
+	case <-events:
+		t.Fatal("a retried notification was dispatched again")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestWebhookRevocation(t *testing.T) {
+	t.Parallel()
+
+	handler := twitch.NewWebhookHandler(testWebhookSecret)
+
+	revocations := make(chan twitch.RevokeMessage, 1)
+	handler.OnRevoke(func(message twitch.RevokeMessage) {
+		revocations <- message
+	})
+
+	body := `{"subscription": {"id": "sub", "status": "authorization_revoked", "type": "user.authorization.revoke", "version": "1"}}`
+	code, _ := serveWebhook(handler, newWebhookRequest(t, testWebhookSecret, "revocation", time.Now(), body))
+	assert.Equal(t, http.StatusNoContent, code)
+
+	select {
+	case message := <-revocations:
+		assert.Equal(t, twitch.RevocationAuthorizationRevoked, message.Reason())
+		assert.Equal(t, "message-id", message.Metadata.MessageID)
+	case <-time.After(time.Second):
+		t.Fatal("revocation was not dispatched")
+	}
+}
+
+func TestWebhookSecrets(t *testing.T) {
+	t.Parallel()
+
+	secrets := twitch.NewWebhookSecrets()
+	request, err := secrets.Assign(twitch.SubscribeRequest{
+		Transport:         twitch.TransportWebhook,
+		Callback:          "https://example.com/eventsub",
+		DeclaredTokenType: twitch.TokenApp,
+		Event:             twitch.SubStreamOnline,
+		TypedCondition:    twitch.BroadcasterCondition{BroadcasterUserID: "1337"},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, request.Validate(), "generated secrets should be valid")
+
+	handler := twitch.NewWebhookHandler("")
+	handler.SecretLookup = secrets.Lookup
+	handler.OnError(func(err error) {})
+
+	body := `{"challenge": "challenge", "subscription": {"id": "sub", "type": "stream.online", "version": "1", "condition": {"broadcaster_user_id": "1337"}}}`
+	code, response := serveWebhook(handler, newWebhookRequest(t, request.Secret, "webhook_callback_verification", time.Now(), body))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "challenge", response)
+
+	other := `{"challenge": "challenge", "subscription": {"id": "sub", "type": "stream.online", "version": "1", "condition": {"broadcaster_user_id": "1"}}}`
+	code, _ = serveWebhook(handler, newWebhookRequest(t, request.Secret, "webhook_callback_verification", time.Now(), other))
+	assert.Equal(t, http.StatusForbidden, code, "the secret belongs to another subscription")
+}
+
+func TestSubscribeWithWebhookTransport(t *testing.T) {
+	t.Parallel()
+
+	helix := &fakeHelixSubscriptions{maxTotalCost: 10}
+	server := httptest.NewServer(helix)
+	defer server.Close()
+
+	resp, err := newTestSubscriptionClient(server.URL).Subscribe(context.Background(), twitch.SubscribeRequest{
+		Transport:      twitch.TransportWebhook,
+		Callback:       "https://example.com/eventsub",
+		Secret:         "0123456789abcdef",
+		Event:          twitch.SubDropEntitlementGrant,
+		TypedCondition: twitch.DropEntitlementCondition{OrganizationID: "1"},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Data, 1) {
+		assert.Equal(t, "https://example.com/eventsub", resp.Data[0].Transport.Callback)
+	}
+}